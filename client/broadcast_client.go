@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TransactionSender sends signed transactions to a given chain.
+// It is implemented by `MultichainBlockchainClient`.
+type TransactionSender interface {
+	SendTransaction(chainID int64, tx *types.Transaction) error
+}
+
+// BroadcastClient sends transactions to multiple nodes at once
+// to speed up their propagation through the network.
+type BroadcastClient struct {
+	senders []TransactionSender
+}
+
+// NewBroadcastClient returns a new broadcast client sending to all the given senders.
+func NewBroadcastClient(senders []TransactionSender) (*BroadcastClient, error) {
+	if len(senders) == 0 {
+		return nil, errors.New("expected more than 0 senders to use")
+	}
+
+	return &BroadcastClient{
+		senders: senders,
+	}, nil
+}
+
+// SendRawTransaction sends the signed transaction to all nodes concurrently.
+// It returns as soon as one of the nodes accepts the transaction.
+// If all of them fail, the errors of every node are returned.
+func (c *BroadcastClient) SendRawTransaction(ctx context.Context, chainID int64, tx *types.Transaction) error {
+	results := make(chan error, len(c.senders))
+	for _, s := range c.senders {
+		go func(s TransactionSender) {
+			results <- s.SendTransaction(chainID, tx)
+		}(s)
+	}
+
+	errs := make([]error, 0, len(c.senders))
+	for range c.senders {
+		select {
+		case err := <-results:
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err)
+		case <-ctx.Done():
+			return fmt.Errorf("failed to broadcast transaction %s: %w", tx.Hash().Hex(), ctx.Err())
+		}
+	}
+
+	return fmt.Errorf("failed to broadcast transaction %s: %w", tx.Hash().Hex(), errors.Join(errs...))
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type senderFunc func(chainID int64, tx *types.Transaction) error
+
+func (f senderFunc) SendTransaction(chainID int64, tx *types.Transaction) error {
+	return f(chainID, tx)
+}
+
+func TestBroadcastClient(t *testing.T) {
+	tx := types.NewTx(&types.DynamicFeeTx{})
+	failing := senderFunc(func(int64, *types.Transaction) error {
+		return errors.New("node down")
+	})
+
+	t.Run("returns on first success", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		hanging := senderFunc(func(int64, *types.Transaction) error {
+			<-block
+			return nil
+		})
+		sent := make(chan int64, 1)
+		working := senderFunc(func(chainID int64, got *types.Transaction) error {
+			assert.Equal(t, tx.Hash(), got.Hash())
+			sent <- chainID
+			return nil
+		})
+
+		c, err := NewBroadcastClient([]TransactionSender{failing, hanging, working})
+		assert.NoError(t, err)
+		assert.NoError(t, c.SendRawTransaction(context.Background(), 5, tx))
+		assert.Equal(t, int64(5), <-sent)
+	})
+
+	t.Run("returns all errors if every node fails", func(t *testing.T) {
+		other := senderFunc(func(int64, *types.Transaction) error {
+			return errors.New("nonce too low")
+		})
+
+		c, err := NewBroadcastClient([]TransactionSender{failing, other})
+		assert.NoError(t, err)
+
+		err = c.SendRawTransaction(context.Background(), 5, tx)
+		assert.ErrorContains(t, err, "node down")
+		assert.ErrorContains(t, err, "nonce too low")
+	})
+
+	t.Run("gives up when context is done", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		hanging := senderFunc(func(int64, *types.Transaction) error {
+			<-block
+			return nil
+		})
+
+		c, err := NewBroadcastClient([]TransactionSender{failing, hanging})
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+		defer cancel()
+		assert.ErrorIs(t, c.SendRawTransaction(ctx, 5, tx), context.DeadlineExceeded)
+	})
+
+	t.Run("requires senders", func(t *testing.T) {
+		_, err := NewBroadcastClient(nil)
+		assert.Error(t, err)
+	})
+}
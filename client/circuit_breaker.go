@@ -0,0 +1,158 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrCircuitOpen is returned instead of calling the node while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets all calls through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fails all calls without reaching the node.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe call through to check if the node recovered.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreaker opens after a number of consecutive failed calls and
+// fails fast for the given duration. After that a single probe call is let
+// through: if it succeeds the breaker closes, otherwise it opens again.
+//
+// Errors returned by the node itself (e.g. a reverted call) and not found
+// responses mean the node is healthy and are not counted as failures.
+// Calls cancelled by the caller are neither failures nor successes.
+type CircuitBreaker struct {
+	failureThreshold int
+	openFor          time.Duration
+
+	lock     sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a new circuit breaker which opens after failureThreshold
+// consecutive failures and stays open for the given duration.
+func NewCircuitBreaker(failureThreshold int, openFor time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openFor:          openFor,
+		state:            CircuitClosed,
+	}
+}
+
+// State returns the current state of the circuit breaker.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.openFor {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// Do calls the given function if the circuit breaker allows it and records its result.
+func (cb *CircuitBreaker) Do(fn func() error) error {
+	if err := cb.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	cb.done(err)
+	return err
+}
+
+func (cb *CircuitBreaker) allow() error {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if time.Since(cb.openedAt) < cb.openFor {
+			return ErrCircuitOpen
+		}
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+		return nil
+	case CircuitHalfOpen:
+		if cb.probing {
+			return ErrCircuitOpen
+		}
+		cb.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (cb *CircuitBreaker) done(err error) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	// Cancelled calls say nothing about the node, only release the probe.
+	if errors.Is(err, context.Canceled) {
+		cb.probing = false
+		return
+	}
+
+	if !isNodeFailure(err) {
+		cb.state = CircuitClosed
+		cb.failures = 0
+		cb.probing = false
+		return
+	}
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.failureThreshold {
+		cb.state = CircuitOpen
+		cb.openedAt = time.Now()
+		cb.probing = false
+	}
+}
+
+func isNodeFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
@@ -0,0 +1,397 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CircuitBreakerEthClient is a ethereum client which stops calling a degraded node
+// once the wrapped `CircuitBreaker` opens and fails fast with `ErrCircuitOpen` instead.
+type CircuitBreakerEthClient struct {
+	client  EtherClient
+	breaker *CircuitBreaker
+}
+
+// NewCircuitBreakerEthClient wraps the given client with the given circuit breaker.
+func NewCircuitBreakerEthClient(client EtherClient, breaker *CircuitBreaker) *CircuitBreakerEthClient {
+	return &CircuitBreakerEthClient{
+		client:  client,
+		breaker: breaker,
+	}
+}
+
+// Client implements the EthClientGetter interface and returns itself as a EtherClient.
+func (c *CircuitBreakerEthClient) Client() EtherClient {
+	return c
+}
+
+// Close closes the underlying client.
+func (c *CircuitBreakerEthClient) Close() {
+	c.client.Close()
+}
+
+// ChainId retrieves the current chain ID for transaction replay protection.
+func (c *CircuitBreakerEthClient) ChainID(ctx context.Context) (*big.Int, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.ChainID(ctx)
+	c.breaker.done(err)
+	return res, err
+}
+
+// BlockByHash returns the given full block.
+//
+// Note that loading full blocks requires two requests. Use HeaderByHash
+// if you don't need all transactions or uncle headers.
+func (c *CircuitBreakerEthClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.BlockByHash(ctx, hash)
+	c.breaker.done(err)
+	return res, err
+}
+
+// BlockByNumber returns a block from the current canonical chain. If number is nil, the
+// latest known block is returned.
+//
+// Note that loading full blocks requires two requests. Use HeaderByNumber
+// if you don't need all transactions or uncle headers.
+func (c *CircuitBreakerEthClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.BlockByNumber(ctx, number)
+	c.breaker.done(err)
+	return res, err
+}
+
+// BlockNumber returns the most recent block number
+func (c *CircuitBreakerEthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	if err := c.breaker.allow(); err != nil {
+		return 0, err
+	}
+	res, err := c.client.BlockNumber(ctx)
+	c.breaker.done(err)
+	return res, err
+}
+
+// HeaderByHash returns the block header with the given hash.
+func (c *CircuitBreakerEthClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.HeaderByHash(ctx, hash)
+	c.breaker.done(err)
+	return res, err
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (c *CircuitBreakerEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.HeaderByNumber(ctx, number)
+	c.breaker.done(err)
+	return res, err
+}
+
+// TransactionByHash returns the transaction with the given hash.
+func (c *CircuitBreakerEthClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, false, err
+	}
+	res, isPending, err := c.client.TransactionByHash(ctx, hash)
+	c.breaker.done(err)
+	return res, isPending, err
+}
+
+// TransactionSender returns the sender address of the given transaction. The transaction
+// must be known to the remote node and included in the blockchain at the given block and
+// index. The sender is the one derived by the protocol at the time of inclusion.
+//
+// There is a fast-path for transactions retrieved by TransactionByHash and
+// TransactionInBlock. Getting their sender address can be done without an RPC interaction.
+func (c *CircuitBreakerEthClient) TransactionSender(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (common.Address, error) {
+	if err := c.breaker.allow(); err != nil {
+		return common.Address{}, err
+	}
+	res, err := c.client.TransactionSender(ctx, tx, block, index)
+	c.breaker.done(err)
+	return res, err
+}
+
+// TransactionCount returns the total number of transactions in the given block.
+func (c *CircuitBreakerEthClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	if err := c.breaker.allow(); err != nil {
+		return 0, err
+	}
+	res, err := c.client.TransactionCount(ctx, blockHash)
+	c.breaker.done(err)
+	return res, err
+}
+
+// TransactionInBlock returns a single transaction at index in the given block.
+func (c *CircuitBreakerEthClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.TransactionInBlock(ctx, blockHash, index)
+	c.breaker.done(err)
+	return res, err
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+// Note that the receipt is not available for pending transactions.
+func (c *CircuitBreakerEthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.TransactionReceipt(ctx, txHash)
+	c.breaker.done(err)
+	return res, err
+}
+
+// SyncProgress retrieves the current progress of the sync algorithm. If there's
+// no sync currently running, it returns nil.
+func (c *CircuitBreakerEthClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.SyncProgress(ctx)
+	c.breaker.done(err)
+	return res, err
+}
+
+// SubscribeNewHead subscribes to notifications about the current blockchain head
+// on the given channel.
+func (c *CircuitBreakerEthClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.SubscribeNewHead(ctx, ch)
+	c.breaker.done(err)
+	return res, err
+}
+
+// NetworkID returns the network ID (also known as the chain ID) for this chain.
+func (c *CircuitBreakerEthClient) NetworkID(ctx context.Context) (*big.Int, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.NetworkID(ctx)
+	c.breaker.done(err)
+	return res, err
+}
+
+// BalanceAt returns the wei balance of the given account.
+// The block number can be nil, in which case the balance is taken from the latest known block.
+func (c *CircuitBreakerEthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.BalanceAt(ctx, account, blockNumber)
+	c.breaker.done(err)
+	return res, err
+}
+
+// StorageAt returns the value of key in the contract storage of the given account.
+// The block number can be nil, in which case the value is taken from the latest known block.
+func (c *CircuitBreakerEthClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.StorageAt(ctx, account, key, blockNumber)
+	c.breaker.done(err)
+	return res, err
+}
+
+// CodeAt returns the contract code of the given account.
+// The block number can be nil, in which case the code is taken from the latest known block.
+func (c *CircuitBreakerEthClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.CodeAt(ctx, account, blockNumber)
+	c.breaker.done(err)
+	return res, err
+}
+
+// NonceAt returns the account nonce of the given account.
+// The block number can be nil, in which case the nonce is taken from the latest known block.
+func (c *CircuitBreakerEthClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if err := c.breaker.allow(); err != nil {
+		return 0, err
+	}
+	res, err := c.client.NonceAt(ctx, account, blockNumber)
+	c.breaker.done(err)
+	return res, err
+}
+
+// FilterLogs executes a filter query.
+func (c *CircuitBreakerEthClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.FilterLogs(ctx, q)
+	c.breaker.done(err)
+	return res, err
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query.
+func (c *CircuitBreakerEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.SubscribeFilterLogs(ctx, q, ch)
+	c.breaker.done(err)
+	return res, err
+}
+
+// PendingBalanceAt returns the wei balance of the given account in the pending state.
+func (c *CircuitBreakerEthClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.PendingBalanceAt(ctx, account)
+	c.breaker.done(err)
+	return res, err
+}
+
+// PendingStorageAt returns the value of key in the contract storage of the given account in the pending state.
+func (c *CircuitBreakerEthClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.PendingStorageAt(ctx, account, key)
+	c.breaker.done(err)
+	return res, err
+}
+
+// PendingCodeAt returns the contract code of the given account in the pending state.
+func (c *CircuitBreakerEthClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.PendingCodeAt(ctx, account)
+	c.breaker.done(err)
+	return res, err
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+// This is the nonce that should be used for the next transaction.
+func (c *CircuitBreakerEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if err := c.breaker.allow(); err != nil {
+		return 0, err
+	}
+	res, err := c.client.PendingNonceAt(ctx, account)
+	c.breaker.done(err)
+	return res, err
+}
+
+// PendingTransactionCount returns the total number of transactions in the pending state.
+func (c *CircuitBreakerEthClient) PendingTransactionCount(ctx context.Context) (uint, error) {
+	if err := c.breaker.allow(); err != nil {
+		return 0, err
+	}
+	res, err := c.client.PendingTransactionCount(ctx)
+	c.breaker.done(err)
+	return res, err
+}
+
+// CallContract executes a message call transaction, which is directly executed in the VM
+// of the node, but never mined into the blockchain.
+//
+// blockNumber selects the block height at which the call runs. It can be nil, in which
+// case the code is taken from the latest known block. Note that state from very old
+// blocks might not be available.
+func (c *CircuitBreakerEthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.CallContract(ctx, msg, blockNumber)
+	c.breaker.done(err)
+	return res, err
+}
+
+// PendingCallContract executes a message call transaction using the EVM.
+// The state seen by the contract call is the pending state.
+func (c *CircuitBreakerEthClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.PendingCallContract(ctx, msg)
+	c.breaker.done(err)
+	return res, err
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price to allow a timely
+// execution of a transaction.
+func (c *CircuitBreakerEthClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.SuggestGasPrice(ctx)
+	c.breaker.done(err)
+	return res, err
+}
+
+// SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
+// a timely execution of a transaction.
+func (c *CircuitBreakerEthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	res, err := c.client.SuggestGasTipCap(ctx)
+	c.breaker.done(err)
+	return res, err
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction based on
+// the current pending state of the backend blockchain. There is no guarantee that this is
+// the true gas limit requirement as other transactions may be added or removed by miners,
+// but it should provide a basis for setting a reasonable default.
+func (c *CircuitBreakerEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if err := c.breaker.allow(); err != nil {
+		return 0, err
+	}
+	res, err := c.client.EstimateGas(ctx, msg)
+	c.breaker.done(err)
+	return res, err
+}
+
+// SendTransaction injects a signed transaction into the pending pool for execution.
+//
+// If the transaction was a contract creation use the TransactionReceipt method to get the
+// contract address after the transaction has been mined.
+func (c *CircuitBreakerEthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	err := c.client.SendTransaction(ctx, tx)
+	c.breaker.done(err)
+	return err
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/payments/v3/client/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRPCError struct{}
+
+func (testRPCError) Error() string  { return "execution reverted" }
+func (testRPCError) ErrorCode() int { return 3 }
+
+func TestCircuitBreaker(t *testing.T) {
+	errNode := errors.New("connection reset")
+	fail := func() error { return errNode }
+	succeed := func() error { return nil }
+
+	t.Run("open, half-open and closed transitions", func(t *testing.T) {
+		cb := NewCircuitBreaker(2, time.Millisecond*50)
+		assert.Equal(t, CircuitClosed, cb.State())
+
+		assert.ErrorIs(t, cb.Do(fail), errNode)
+		assert.Equal(t, CircuitClosed, cb.State())
+		assert.ErrorIs(t, cb.Do(fail), errNode)
+		assert.Equal(t, CircuitOpen, cb.State())
+
+		called := false
+		err := cb.Do(func() error {
+			called = true
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.False(t, called)
+
+		time.Sleep(time.Millisecond * 60)
+		assert.Equal(t, CircuitHalfOpen, cb.State())
+
+		assert.NoError(t, cb.Do(succeed))
+		assert.Equal(t, CircuitClosed, cb.State())
+	})
+
+	t.Run("failed probe opens the breaker again", func(t *testing.T) {
+		cb := NewCircuitBreaker(1, time.Millisecond*50)
+		assert.ErrorIs(t, cb.Do(fail), errNode)
+		assert.Equal(t, CircuitOpen, cb.State())
+
+		time.Sleep(time.Millisecond * 60)
+		assert.ErrorIs(t, cb.Do(fail), errNode)
+		assert.Equal(t, CircuitOpen, cb.State())
+		assert.ErrorIs(t, cb.Do(succeed), ErrCircuitOpen)
+	})
+
+	t.Run("only one probe is let through while half-open", func(t *testing.T) {
+		cb := NewCircuitBreaker(1, time.Millisecond*50)
+		assert.ErrorIs(t, cb.Do(fail), errNode)
+		time.Sleep(time.Millisecond * 60)
+
+		release := make(chan struct{})
+		probeDone := make(chan error)
+		go func() {
+			probeDone <- cb.Do(func() error {
+				<-release
+				return nil
+			})
+		}()
+
+		assert.Eventually(t, func() bool {
+			return errors.Is(cb.Do(succeed), ErrCircuitOpen)
+		}, time.Second, time.Millisecond)
+
+		close(release)
+		assert.NoError(t, <-probeDone)
+		assert.Equal(t, CircuitClosed, cb.State())
+	})
+
+	t.Run("node responses are not failures", func(t *testing.T) {
+		cb := NewCircuitBreaker(1, time.Minute)
+		assert.Error(t, cb.Do(func() error { return ethereum.NotFound }))
+		assert.Error(t, cb.Do(func() error { return testRPCError{} }))
+		assert.Equal(t, CircuitClosed, cb.State())
+	})
+
+	t.Run("cancelled calls do not change the state", func(t *testing.T) {
+		cancelled := func() error { return context.Canceled }
+
+		cb := NewCircuitBreaker(2, time.Millisecond*50)
+		assert.ErrorIs(t, cb.Do(fail), errNode)
+		assert.ErrorIs(t, cb.Do(cancelled), context.Canceled)
+		assert.Equal(t, CircuitClosed, cb.State())
+
+		// Failure count was kept, so the next failure opens the breaker.
+		assert.ErrorIs(t, cb.Do(fail), errNode)
+		assert.Equal(t, CircuitOpen, cb.State())
+
+		// A cancelled probe releases the slot but keeps the breaker half-open.
+		time.Sleep(time.Millisecond * 60)
+		assert.ErrorIs(t, cb.Do(cancelled), context.Canceled)
+		assert.Equal(t, CircuitHalfOpen, cb.State())
+
+		assert.NoError(t, cb.Do(succeed))
+		assert.Equal(t, CircuitClosed, cb.State())
+	})
+}
+
+func TestCircuitBreakerEthClient(t *testing.T) {
+	cl := &mocks.EtherClientMock{
+		PendingNonceAtFunc: func(ctx context.Context, account common.Address) (uint64, error) {
+			return 0, errors.New("connection refused")
+		},
+	}
+	c := NewCircuitBreakerEthClient(cl, NewCircuitBreaker(3, time.Minute))
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Client().PendingNonceAt(context.Background(), common.Address{})
+		assert.EqualError(t, err, "connection refused")
+	}
+
+	_, err := c.Client().PendingNonceAt(context.Background(), common.Address{})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Len(t, cl.PendingNonceAtCalls(), 3)
+}
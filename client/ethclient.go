@@ -19,7 +19,9 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -47,8 +49,20 @@ type ReconnectableEthClient struct {
 	address string
 	mu      sync.Mutex
 	client  *ethclient.Client
+
+	stop    chan struct{}
+	stopped chan struct{}
+
+	// chainID is cached on first use. It is marked stale
+	// after a reconnect to be checked against the new node.
+	chainID      *big.Int
+	chainIDStale bool
 }
 
+// ErrChainIDChanged is returned when after a reconnect the node reports
+// a different chain ID than the one cached before.
+var ErrChainIDChanged = errors.New("chain ID changed after reconnect")
+
 // Client returns the currently connected ethereum client.
 func (c *ReconnectableEthClient) Client() EtherClient {
 	c.mu.Lock()
@@ -65,6 +79,45 @@ func (c *ReconnectableEthClient) Address() string {
 	return c.address
 }
 
+// CachedChainID returns the chain ID of the connected node, only calling
+// the node the first time and after every reconnect. If the node reports
+// a different chain ID after a reconnect, ErrChainIDChanged is returned.
+func (c *ReconnectableEthClient) CachedChainID(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	if c.chainID != nil && !c.chainIDStale {
+		defer c.mu.Unlock()
+		return c.chainID.Int64(), nil
+	}
+	client := c.client
+	c.mu.Unlock()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not get chain ID: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.chainID != nil && c.chainID.Cmp(chainID) != 0 {
+		return 0, fmt.Errorf("%w: expected %s, got %s", ErrChainIDChanged, c.chainID, chainID)
+	}
+
+	c.chainID = chainID
+	c.chainIDStale = false
+	return chainID.Int64(), nil
+}
+
+// Ping performs a lightweight call to the connected node and
+// returns nil if the connection is healthy.
+func (c *ReconnectableEthClient) Ping(ctx context.Context) error {
+	if _, err := c.Client().BlockNumber(ctx); err != nil {
+		return fmt.Errorf("ethereum client is not healthy: %w", err)
+	}
+
+	return nil
+}
+
 // Reconnect creates new ethereum client and replaces the current one.
 func (c *ReconnectableEthClient) Reconnect(connectTimeout time.Duration) error {
 	c.mu.Lock()
@@ -78,8 +131,111 @@ func (c *ReconnectableEthClient) Reconnect(connectTimeout time.Duration) error {
 		return fmt.Errorf("ethereum client failed to dial: %w", err)
 	}
 
+	c.replaceClient(client)
+	return nil
+}
+
+// ReconnectWithBackoff keeps trying to create a new ethereum client until it succeeds,
+// waiting exponentially longer between attempts starting with base and capped at max.
+// It gives up and returns the context error once ctx is done.
+//
+// The current client stays usable while reconnecting, it is only
+// replaced once a new connection is established.
+func (c *ReconnectableEthClient) ReconnectWithBackoff(ctx context.Context, base, max time.Duration) error {
+	address := c.Address()
+
+	delay := base
+	for {
+		client, err := ethclient.DialContext(ctx, address)
+		if err == nil {
+			c.mu.Lock()
+			c.replaceClient(client)
+			c.mu.Unlock()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ethereum client failed to dial: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > max {
+			delay = max
+		}
+	}
+}
+
+// replaceClient swaps the underlying client in place so that
+// previously returned clients remain usable. Must be called with mu held.
+func (c *ReconnectableEthClient) replaceClient(client *ethclient.Client) {
 	c.client.Close()
 	*c.client = *client
+	c.chainIDStale = true
+}
 
-	return nil
+// StartAutoReconnect spawns a goroutine which pings the node every interval
+// and reconnects if the connection is found to be unhealthy.
+// Errors of both the health check and the reconnection are passed to onError.
+//
+// Calling it again while auto reconnect is already running does nothing.
+func (c *ReconnectableEthClient) StartAutoReconnect(interval time.Duration, onError func(error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stop != nil {
+		return
+	}
+
+	if onError == nil {
+		onError = func(error) {}
+	}
+
+	c.stop = make(chan struct{})
+	c.stopped = make(chan struct{})
+	go func(stop, stopped chan struct{}) {
+		defer close(stopped)
+		c.autoReconnect(interval, onError, stop)
+	}(c.stop, c.stopped)
+}
+
+// Stop terminates the auto reconnect goroutine if one is running
+// and waits for an ongoing health check to finish.
+func (c *ReconnectableEthClient) Stop() {
+	c.mu.Lock()
+	stop, stopped := c.stop, c.stopped
+	c.stop, c.stopped = nil, nil
+	c.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-stopped
+}
+
+func (c *ReconnectableEthClient) autoReconnect(interval time.Duration, onError func(error), stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			err := c.Ping(ctx)
+			cancel()
+			if err == nil {
+				continue
+			}
+
+			onError(err)
+			if err := c.Reconnect(interval); err != nil {
+				onError(err)
+			}
+		}
+	}
 }
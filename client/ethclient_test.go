@@ -18,9 +18,16 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,3 +54,216 @@ func TestReconnectableEthClientCreatesNewClient(t *testing.T) {
 	assert.Equal(t, a1, a3)
 	assert.Equal(t, a2, a3)
 }
+
+func TestReconnectableEthClientPing(t *testing.T) {
+	node := newFakeNode()
+	defer node.Close()
+
+	client, err := NewReconnectableEthClient(node.URL, time.Second)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, client.Ping(ctx))
+
+	node.setHealthy(false)
+	assert.Error(t, client.Ping(ctx))
+
+	node.setHealthy(true)
+	assert.NoError(t, client.Ping(ctx))
+}
+
+func TestReconnectableEthClientAutoReconnect(t *testing.T) {
+	node, url, dropConnections := newFakeLogsNode(t)
+
+	client, err := NewReconnectableEthClient(url, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, node.acceptedCount())
+
+	// Client is replaced in place, so compare the underlying connection.
+	rpcClient := func() *rpc.Client {
+		return client.Client().(*ethclient.Client).Client()
+	}
+	old := rpcClient()
+
+	var failures int32
+	client.StartAutoReconnect(time.Millisecond*10, func(err error) {
+		atomic.AddInt32(&failures, 1)
+	})
+	defer client.Stop()
+
+	// Starting twice should not spawn another goroutine.
+	client.StartAutoReconnect(time.Millisecond*10, nil)
+
+	// Healthy connection is kept.
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&failures))
+	assert.Equal(t, 1, node.acceptedCount())
+
+	// Broken connection is detected and a new client is dialled.
+	dropConnections()
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&failures) > 0 && node.acceptedCount() > 1
+	}, time.Second, time.Millisecond*10)
+
+	// Stop waits for the ongoing reconnect to finish.
+	client.Stop()
+	assert.NotSame(t, old, rpcClient(), "client was not replaced")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, client.Ping(ctx))
+
+	stoppedAt := atomic.LoadInt32(&failures)
+	accepted := node.acceptedCount()
+
+	dropConnections()
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, stoppedAt, atomic.LoadInt32(&failures))
+	assert.Equal(t, accepted, node.acceptedCount())
+}
+
+func TestReconnectableEthClientReconnectWithBackoff(t *testing.T) {
+	node := newFakeNode()
+	defer node.Close()
+
+	client, err := NewReconnectableEthClient(node.URL, time.Second)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, client.ReconnectWithBackoff(ctx, time.Millisecond, time.Millisecond*10))
+	assert.NoError(t, client.Ping(ctx))
+
+	t.Run("gives up when context is done", func(t *testing.T) {
+		// Websocket dial fails right away if nobody is listening.
+		client.address = "ws://127.0.0.1:1"
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+
+		err := client.ReconnectWithBackoff(ctx, time.Millisecond, time.Millisecond*10)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("does not block the client while reconnecting", func(t *testing.T) {
+		client.address = "ws://127.0.0.1:1"
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- client.ReconnectWithBackoff(ctx, time.Millisecond*10, time.Millisecond*10)
+		}()
+		defer func() {
+			cancel()
+			assert.ErrorIs(t, <-done, context.Canceled)
+		}()
+
+		// Let the reconnect go through a few attempts.
+		time.Sleep(time.Millisecond * 50)
+
+		returned := make(chan struct{})
+		go func() {
+			client.Client()
+			client.Address()
+			close(returned)
+		}()
+
+		select {
+		case <-returned:
+		case <-time.After(time.Second):
+			t.Fatal("client is blocked by an ongoing reconnect")
+		}
+	})
+}
+
+func TestReconnectableEthClientCachedChainID(t *testing.T) {
+	node := newFakeNode()
+	defer node.Close()
+
+	client, err := NewReconnectableEthClient(node.URL, time.Second)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	chainID, err := client.CachedChainID(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16), chainID)
+
+	// Cached value is used until reconnect.
+	node.setResult("0x20")
+	chainID, err = client.CachedChainID(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16), chainID)
+
+	assert.NoError(t, client.Reconnect(time.Second))
+	_, err = client.CachedChainID(ctx)
+	assert.ErrorIs(t, err, ErrChainIDChanged)
+
+	node.setResult("0x10")
+	chainID, err = client.CachedChainID(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16), chainID)
+}
+
+// fakeNode is a minimal JSON-RPC node which answers every call with the same
+// quantity, enough for `eth_blockNumber` and `eth_chainId`, and can be made
+// to drop all incoming connections.
+type fakeNode struct {
+	*httptest.Server
+	unhealthy int32
+	result    atomic.Value
+}
+
+func newFakeNode() *fakeNode {
+	n := &fakeNode{}
+	n.result.Store("0x10")
+	n.Server = httptest.NewServer(http.HandlerFunc(n.handle))
+	return n
+}
+
+func (n *fakeNode) setResult(result string) {
+	n.result.Store(result)
+}
+
+func (n *fakeNode) setHealthy(healthy bool) {
+	var v int32
+	if !healthy {
+		v = 1
+	}
+	atomic.StoreInt32(&n.unhealthy, v)
+}
+
+func (n *fakeNode) handle(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&n.unhealthy) == 1 {
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		conn, _, err := hj.Hijack()
+		if err == nil {
+			conn.Close()
+		}
+		return
+	}
+
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      req.ID,
+		"result":  n.result.Load().(string),
+	})
+}
@@ -22,8 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -46,6 +48,13 @@ type EthMultiClient struct {
 	// when a certain client goes down.
 	notifyDown *safeChannel
 
+	// roundRobin spreads calls across clients instead of always starting with the first one.
+	roundRobin bool
+	next       int
+	// downFor is how long a client which failed is kept out of rotation.
+	downFor   time.Duration
+	downUntil map[string]time.Time
+
 	mu sync.Mutex
 }
 
@@ -79,6 +88,23 @@ func NewEthMultiClient(defaulTimeout time.Duration, clients []AddressableEthClie
 	}, nil
 }
 
+// NewEthMultiClientRoundRobin creates a new multi clients eth client which
+// starts every call with the next client in turn, spreading the load across them.
+//
+// Clients which fail to respond are skipped for the downFor duration and only
+// called if all other clients fail as well.
+func NewEthMultiClientRoundRobin(defaulTimeout time.Duration, clients []AddressableEthClientGetter, downFor time.Duration) (*EthMultiClient, error) {
+	c, err := NewEthMultiClient(defaulTimeout, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	c.roundRobin = true
+	c.downFor = downFor
+	c.downUntil = make(map[string]time.Time)
+	return c, nil
+}
+
 // Client implements the EthClientGetter interface and returns itself as a EtherClient.
 func (c *EthMultiClient) Client() EtherClient {
 	return c
@@ -651,10 +677,16 @@ func (c *EthMultiClient) doWithMultipleClients(ctx context.Context, do func(ctx
 // If parent context is cancel or receives a timeout, all calls will be also cancels and the
 // function will return.
 func (c *EthMultiClient) doWithAllClients(ctx context.Context, do func(ctx context.Context, c EtherClient) error, returnOnFirstSuccess bool) error {
+	// Only pick the clients under the lock, so that concurrent
+	// calls are not serialized while waiting for the nodes.
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	clients := c.clients
+	if returnOnFirstSuccess {
+		clients = c.rotateClients()
+	}
+	c.mu.Unlock()
 
-	ctxs, cancel := c.produceCtxs(ctx)
+	ctxs, cancel := c.produceCtxs(ctx, len(clients))
 	defer cancel()
 
 	done := make(chan struct{})
@@ -669,7 +701,7 @@ func (c *EthMultiClient) doWithAllClients(ctx context.Context, do func(ctx conte
 		}
 	}()
 
-	for i, cl := range c.clients {
+	for i, cl := range clients {
 		select {
 		case <-ctx.Done():
 			return context.DeadlineExceeded
@@ -682,11 +714,13 @@ func (c *EthMultiClient) doWithAllClients(ctx context.Context, do func(ctx conte
 			err := do(childCtx, cl.Client())
 			if err != nil {
 				if c.tryNotify(ctx, cl.Address(), err) {
+					c.markDown(cl.Address())
 					continue
 				}
 				return err
 			}
 
+			c.markUp(cl.Address())
 			if returnOnFirstSuccess {
 				return nil
 			}
@@ -695,6 +729,52 @@ func (c *EthMultiClient) doWithAllClients(ctx context.Context, do func(ctx conte
 	return nil
 }
 
+// rotateClients returns clients in the order they should be called.
+// In round robin mode each call starts with the next client
+// and clients which are down are moved to the back.
+// It must be called with c.mu held.
+func (c *EthMultiClient) rotateClients() []AddressableEthClientGetter {
+	if !c.roundRobin {
+		return c.clients
+	}
+
+	start := c.next
+	c.next = (c.next + 1) % len(c.clients)
+
+	up := make([]AddressableEthClientGetter, 0, len(c.clients))
+	var down []AddressableEthClientGetter
+	for i := range c.clients {
+		cl := c.clients[(start+i)%len(c.clients)]
+		if until, ok := c.downUntil[cl.Address()]; ok && time.Now().Before(until) {
+			down = append(down, cl)
+			continue
+		}
+		up = append(up, cl)
+	}
+
+	return append(up, down...)
+}
+
+func (c *EthMultiClient) markDown(address string) {
+	if !c.roundRobin {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.downUntil[address] = time.Now().Add(c.downFor)
+}
+
+func (c *EthMultiClient) markUp(address string) {
+	if !c.roundRobin {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.downUntil, address)
+}
+
 func (c *EthMultiClient) tryNotify(parentCtx context.Context, clientAddress string, err error) bool {
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
@@ -704,6 +784,8 @@ func (c *EthMultiClient) tryNotify(parentCtx context.Context, clientAddress stri
 		}
 	case strings.Contains(strings.ToLower(err.Error()), "429 too many requests"):
 		c.notify(clientAddress, ErrClientTooManyRequests)
+	case isErrNodeUnreachable(err):
+		c.notify(clientAddress, ErrClientNoConnection)
 	default:
 		return false
 	}
@@ -711,6 +793,18 @@ func (c *EthMultiClient) tryNotify(parentCtx context.Context, clientAddress stri
 	return true
 }
 
+// isErrNodeUnreachable returns true if the node could not be reached
+// at all, e.g. it is down and refuses connections. Errors on an already
+// established connection, such as read timeouts, do not count.
+func isErrNodeUnreachable(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 func (c *EthMultiClient) notify(address string, err error) {
 	c.notifyDown.mu.Lock()
 	defer c.notifyDown.mu.Unlock()
@@ -743,14 +837,15 @@ func (c *EthMultiClient) closeNotify() {
 	}
 }
 
-// produceCtxs produces contexts for each client.
-func (c *EthMultiClient) produceCtxs(ctx context.Context) ([]context.Context, func()) {
-	singleCtxDuration := c.childTimeout(ctx)
-	ctxs := make([]context.Context, len(c.clients))
-	cancels := make([]func(), len(c.clients))
+// produceCtxs produces contexts for each client. They are derived from
+// the given context, so values such as the trace ID reach every node.
+func (c *EthMultiClient) produceCtxs(ctx context.Context, count int) ([]context.Context, func()) {
+	singleCtxDuration := c.childTimeout(ctx, count)
+	ctxs := make([]context.Context, count)
+	cancels := make([]func(), count)
 
 	for i := range ctxs {
-		ctxs[i], cancels[i] = context.WithTimeout(context.Background(), singleCtxDuration*time.Duration((i+1)))
+		ctxs[i], cancels[i] = context.WithTimeout(ctx, singleCtxDuration*time.Duration((i+1)))
 	}
 
 	cancelFn := func() {
@@ -766,12 +861,12 @@ func (c *EthMultiClient) produceCtxs(ctx context.Context) ([]context.Context, fu
 //
 // It rounds the extracted duration down to miliseconds elimiating
 // the posiblity to get context duration like: 1.99999999999s
-func (c *EthMultiClient) childTimeout(ctx context.Context) time.Duration {
+func (c *EthMultiClient) childTimeout(ctx context.Context, count int) time.Duration {
 	deadline, ok := ctx.Deadline()
 	if !ok {
 		deadline = time.Now().Add(c.timeout)
 	}
-	res := time.Until(deadline) / time.Duration(len(c.clients))
+	res := time.Until(deadline) / time.Duration(count)
 
 	// avoid errors if time is actually less
 	if res > time.Millisecond {
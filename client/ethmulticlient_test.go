@@ -19,10 +19,15 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"net"
+	"net/url"
+	"os"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
@@ -355,6 +360,38 @@ func Test_EthMultiClient(t *testing.T) {
 		assert.True(t, len(cl2.ChainIDCalls()) == 1)
 	})
 
+	t.Run("two clients passed first is down, second is used", func(t *testing.T) {
+		cl := &mocks.EtherClientMock{
+			PendingNonceAtFunc: func(ctx context.Context, account common.Address) (uint64, error) {
+				return 0, &url.Error{
+					Op:  "Post",
+					URL: "http://127.0.0.1:8545",
+					Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+				}
+			},
+		}
+		cl2 := &mocks.EtherClientMock{
+			PendingNonceAtFunc: func(ctx context.Context, account common.Address) (uint64, error) {
+				return 5, nil
+			},
+		}
+		getter := NewDefaultAddressableEthClientGetter("", cl)
+		getter2 := NewDefaultAddressableEthClientGetter("", cl2)
+
+		notifications := make(chan Notification, 1)
+		multi, err := NewEthMultiClientNotifyDown(time.Second, []AddressableEthClientGetter{getter, getter2}, notifications)
+		assert.NoError(t, err)
+
+		nonce, err := multi.PendingNonceAt(context.Background(), common.Address{})
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), nonce)
+		assert.Len(t, cl.PendingNonceAtCalls(), 1)
+		assert.Len(t, cl2.PendingNonceAtCalls(), 1)
+
+		n := <-notifications
+		assert.ErrorIs(t, n.Error, ErrClientNoConnection)
+	})
+
 	t.Run("two clients passed first breaks, notification received", func(t *testing.T) {
 		cl := &mocks.EtherClientMock{
 			ChainIDFunc: func(ctx context.Context) (*big.Int, error) {
@@ -618,3 +655,134 @@ func lockAndIncrement(callsCounter map[string]int, lock *sync.Mutex, key string)
 	defer lock.Unlock()
 	callsCounter[key]++
 }
+
+func Test_EthMultiClientRoundRobin(t *testing.T) {
+	var mu sync.Mutex
+	down := false
+	newClient := func(nonce uint64, canGoDown bool) *mocks.EtherClientMock {
+		return &mocks.EtherClientMock{
+			PendingNonceAtFunc: func(ctx context.Context, account common.Address) (uint64, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if canGoDown && down {
+					return 0, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+				}
+				return nonce, nil
+			},
+		}
+	}
+	cl1, cl2, cl3 := newClient(1, false), newClient(2, true), newClient(3, false)
+
+	multi, err := NewEthMultiClientRoundRobin(time.Second, []AddressableEthClientGetter{
+		NewDefaultAddressableEthClientGetter("1", cl1),
+		NewDefaultAddressableEthClientGetter("2", cl2),
+		NewDefaultAddressableEthClientGetter("3", cl3),
+	}, time.Millisecond*100)
+	assert.NoError(t, err)
+
+	call := func() uint64 {
+		nonce, err := multi.PendingNonceAt(context.Background(), common.Address{})
+		assert.NoError(t, err)
+		return nonce
+	}
+
+	t.Run("calls are spread across clients", func(t *testing.T) {
+		assert.Equal(t, []uint64{1, 2, 3, 1}, []uint64{call(), call(), call(), call()})
+	})
+
+	t.Run("client which is down is skipped until it recovers", func(t *testing.T) {
+		mu.Lock()
+		down = true
+		mu.Unlock()
+
+		// Client 2 fails and client 3 takes over.
+		assert.Equal(t, uint64(3), call())
+		assert.Len(t, cl2.PendingNonceAtCalls(), 2)
+
+		// Rotation continues without client 2.
+		assert.Equal(t, []uint64{3, 1, 3}, []uint64{call(), call(), call()})
+		assert.Len(t, cl2.PendingNonceAtCalls(), 2)
+
+		mu.Lock()
+		down = false
+		mu.Unlock()
+		time.Sleep(time.Millisecond * 150)
+
+		assert.Equal(t, []uint64{3, 1, 2}, []uint64{call(), call(), call()})
+	})
+
+	t.Run("concurrent calls are not serialized", func(t *testing.T) {
+		release := make(chan struct{})
+		slow := &mocks.EtherClientMock{
+			PendingNonceAtFunc: func(ctx context.Context, account common.Address) (uint64, error) {
+				<-release
+				return 1, nil
+			},
+		}
+		multi, err := NewEthMultiClientRoundRobin(time.Second, []AddressableEthClientGetter{
+			NewDefaultAddressableEthClientGetter("1", slow),
+			NewDefaultAddressableEthClientGetter("2", newClient(2, false)),
+		}, time.Millisecond*100)
+		assert.NoError(t, err)
+
+		slowDone := make(chan uint64)
+		go func() {
+			nonce, _ := multi.PendingNonceAt(context.Background(), common.Address{})
+			slowDone <- nonce
+		}()
+		assert.Eventually(t, func() bool {
+			return len(slow.PendingNonceAtCalls()) == 1
+		}, time.Second, time.Millisecond)
+
+		// Next client is called while the first call is still waiting.
+		fastDone := make(chan uint64, 1)
+		go func() {
+			nonce, _ := multi.PendingNonceAt(context.Background(), common.Address{})
+			fastDone <- nonce
+		}()
+		select {
+		case nonce := <-fastDone:
+			assert.Equal(t, uint64(2), nonce)
+		case <-time.After(time.Millisecond * 500):
+			t.Error("call was blocked by another ongoing call")
+		}
+
+		close(release)
+		assert.Equal(t, uint64(1), <-slowDone)
+	})
+}
+
+func Test_isErrNodeUnreachable(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		err         error
+		unreachable bool
+	}{
+		{
+			name:        "connection refused",
+			err:         &url.Error{Op: "Post", URL: "http://127.0.0.1:8545", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}},
+			unreachable: true,
+		},
+		{
+			name:        "dial failure",
+			err:         &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")},
+			unreachable: true,
+		},
+		{
+			name: "read timeout",
+			err:  &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded},
+		},
+		{
+			name: "write failure",
+			err:  &net.OpError{Op: "write", Net: "tcp", Err: syscall.EPIPE},
+		},
+		{
+			name: "other error",
+			err:  errors.New("execution reverted"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.unreachable, isErrNodeUnreachable(test.err))
+		})
+	}
+}
@@ -721,6 +721,33 @@ func (mbc *MultichainBlockchainClient) EstimateGas(chainID int64, msg ethereum.C
 	return bc.EstimateGas(msg)
 }
 
+// EstimateGasCost estimates the cost in wei of executing the given message on a given chain.
+// The gas price of the message is used if set, otherwise the price suggested by the node.
+func (mbc *MultichainBlockchainClient) EstimateGasCost(chainID int64, msg ethereum.CallMsg) (*big.Int, error) {
+	bc, err := mbc.GetClientByChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	gas, err := bc.EstimateGas(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not estimate gas")
+	}
+
+	price := msg.GasPrice
+	if price == nil {
+		price = msg.GasFeeCap
+	}
+	if price == nil {
+		price, err = bc.SuggestGasPrice()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get gas price")
+		}
+	}
+
+	return new(big.Int).Mul(new(big.Int).SetUint64(gas), price), nil
+}
+
 func (mbc *MultichainBlockchainClient) SwapExactTokensForETH(chainID int64, req SwapExactTokensForETHReq) (*types.Transaction, error) {
 	bc, err := mbc.GetClientByChain(chainID)
 	if err != nil {
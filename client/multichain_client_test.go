@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/mysteriumnetwork/payments/v3/client/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultichainBlockchainClientEstimateGasCost(t *testing.T) {
+	cl := &mocks.EtherClientMock{
+		EstimateGasFunc: func(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+			if msg.Data != nil {
+				return 0, errors.New("execution reverted")
+			}
+			return 21000, nil
+		},
+		SuggestGasPriceFunc: func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(3), nil
+		},
+	}
+	mbc := NewMultichainBlockchainClient(map[int64]BC{
+		1: NewBlockchain(NewDefaultAddressableEthClientGetter("", cl), time.Second),
+	})
+
+	cost, err := mbc.EstimateGasCost(1, ethereum.CallMsg{})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(63000), cost)
+
+	cost, err = mbc.EstimateGasCost(1, ethereum.CallMsg{GasPrice: big.NewInt(2)})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42000), cost)
+
+	cost, err = mbc.EstimateGasCost(1, ethereum.CallMsg{GasFeeCap: big.NewInt(5)})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(105000), cost)
+	assert.Len(t, cl.SuggestGasPriceCalls(), 1)
+
+	_, err = mbc.EstimateGasCost(1, ethereum.CallMsg{Data: []byte{1}})
+	assert.EqualError(t, err, "could not estimate gas: execution reverted")
+
+	_, err = mbc.EstimateGasCost(2, ethereum.CallMsg{})
+	assert.ErrorIs(t, err, ErrUnknownChain)
+}
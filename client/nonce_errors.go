@@ -0,0 +1,73 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// nonceErrors are the go-ethereum errors which mean that the nonce of
+// a sent transaction conflicts with the sender's account state:
+//
+//   - core.ErrNonceTooLow: nonce was already used by a mined transaction.
+//   - core.ErrNonceTooHigh: nonce is ahead of the account nonce (only returned by some nodes).
+//   - txpool.ErrReplaceUnderpriced: a pending transaction with the same nonce
+//     exists and the new one does not pay enough to replace it.
+var nonceErrors = []error{
+	core.ErrNonceTooLow,
+	core.ErrNonceTooHigh,
+	txpool.ErrReplaceUnderpriced,
+}
+
+// IsNonceError returns true if the error returned while sending a transaction
+// means that its nonce conflicts with the sender's account state, meaning
+// the cached nonce for that sender should be reloaded.
+//
+// Errors returned by an in process backend are matched with errors.Is.
+// Errors returned over JSON-RPC lose their type, so for those the message
+// of the rpc.Error is matched against the messages of the same upstream errors.
+func IsNonceError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, nonceErr := range nonceErrors {
+		if errors.Is(err, nonceErr) {
+			return true
+		}
+	}
+
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+
+	msg := rpcErr.Error()
+	for _, nonceErr := range nonceErrors {
+		if strings.Contains(msg, nonceErr.Error()) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonRPCError struct {
+	msg string
+}
+
+func (e jsonRPCError) Error() string  { return e.msg }
+func (e jsonRPCError) ErrorCode() int { return -32000 }
+
+func TestIsNonceError(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "nonce too low", err: core.ErrNonceTooLow, want: true},
+		{name: "nonce too high wrapped", err: fmt.Errorf("send failed: %w", core.ErrNonceTooHigh), want: true},
+		{name: "replacement underpriced", err: txpool.ErrReplaceUnderpriced, want: true},
+		{name: "json rpc nonce too low", err: jsonRPCError{msg: "nonce too low: address 0x1, tx: 1 state: 2"}, want: true},
+		{name: "json rpc underpriced", err: fmt.Errorf("send: %w", jsonRPCError{msg: "replacement transaction underpriced"}), want: true},
+		{name: "json rpc other", err: jsonRPCError{msg: "insufficient funds for gas * price + value"}, want: false},
+		{name: "plain error with nonce message", err: errors.New("nonce too low"), want: false},
+		{name: "unrelated", err: errors.New("connection refused"), want: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, IsNonceError(test.err))
+		})
+	}
+}
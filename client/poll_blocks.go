@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// PollBlocks polls the node for the latest block number every interval
+// and emits it on the returned channel whenever it advances.
+// It provides a subscription like API for nodes reachable only over HTTP.
+//
+// Polling errors are sent on the error channel and polling continues.
+// Errors are dropped if the error channel is not being read.
+//
+// Both channels are closed once ctx is done.
+func PollBlocks(ctx context.Context, c EtherClient, interval time.Duration) (<-chan uint64, <-chan error) {
+	blocks := make(chan uint64)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(blocks)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last uint64
+		for {
+			block, err := c.BlockNumber(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				select {
+				case errs <- err:
+				default:
+				}
+			} else if block > last {
+				select {
+				case blocks <- block:
+					last = block
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return blocks, errs
+}
+
+// PollBlocks polls for new blocks on a given chain.
+func (mbc *MultichainBlockchainClient) PollBlocks(ctx context.Context, chainID int64, interval time.Duration) (<-chan uint64, <-chan error, error) {
+	bc, err := mbc.GetClientByChain(chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blocks, errs := PollBlocks(ctx, bc.Client(), interval)
+	return blocks, errs, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/payments/v3/client/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollBlocks(t *testing.T) {
+	var mu sync.Mutex
+	responses := []uint64{5, 5, 6, 4, 0, 8}
+	cl := &mocks.EtherClientMock{
+		BlockNumberFunc: func(ctx context.Context) (uint64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if len(responses) == 0 {
+				return 8, nil
+			}
+			block := responses[0]
+			responses = responses[1:]
+			if block == 0 {
+				return 0, errors.New("node unavailable")
+			}
+			return block, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blocks, errs := PollBlocks(ctx, cl, time.Millisecond)
+
+	for _, want := range []uint64{5, 6, 8} {
+		select {
+		case got := <-blocks:
+			assert.Equal(t, want, got)
+		case <-time.After(time.Second):
+			t.Fatal("block not received")
+		}
+	}
+	assert.EqualError(t, <-errs, "node unavailable")
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-blocks
+		return !ok
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,340 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitedEthClient is a ethereum client which caps the rate of outbound calls
+// to stay within the request per second limits enforced by public nodes.
+//
+// Calls exceeding the limit block until they are allowed to proceed
+// or return an error once their context is done.
+type RateLimitedEthClient struct {
+	client  EtherClient
+	limiter *rate.Limiter
+}
+
+// NewRateLimitedEthClient wraps the given client allowing at most callsPerSecond
+// calls on average with bursts of up to burst calls.
+func NewRateLimitedEthClient(client EtherClient, callsPerSecond float64, burst int) *RateLimitedEthClient {
+	return &RateLimitedEthClient{
+		client:  client,
+		limiter: rate.NewLimiter(rate.Limit(callsPerSecond), burst),
+	}
+}
+
+// Client implements the EthClientGetter interface and returns itself as a EtherClient.
+func (c *RateLimitedEthClient) Client() EtherClient {
+	return c
+}
+
+// Close closes the underlying client.
+func (c *RateLimitedEthClient) Close() {
+	c.client.Close()
+}
+
+// ChainId retrieves the current chain ID for transaction replay protection.
+func (c *RateLimitedEthClient) ChainID(ctx context.Context) (*big.Int, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.ChainID(ctx)
+}
+
+// BlockByHash returns the given full block.
+//
+// Note that loading full blocks requires two requests. Use HeaderByHash
+// if you don't need all transactions or uncle headers.
+func (c *RateLimitedEthClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.BlockByHash(ctx, hash)
+}
+
+// BlockByNumber returns a block from the current canonical chain. If number is nil, the
+// latest known block is returned.
+//
+// Note that loading full blocks requires two requests. Use HeaderByNumber
+// if you don't need all transactions or uncle headers.
+func (c *RateLimitedEthClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.BlockByNumber(ctx, number)
+}
+
+// BlockNumber returns the most recent block number
+func (c *RateLimitedEthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return c.client.BlockNumber(ctx)
+}
+
+// HeaderByHash returns the block header with the given hash.
+func (c *RateLimitedEthClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.HeaderByHash(ctx, hash)
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (c *RateLimitedEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.HeaderByNumber(ctx, number)
+}
+
+// TransactionByHash returns the transaction with the given hash.
+func (c *RateLimitedEthClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, false, err
+	}
+	return c.client.TransactionByHash(ctx, hash)
+}
+
+// TransactionSender returns the sender address of the given transaction. The transaction
+// must be known to the remote node and included in the blockchain at the given block and
+// index. The sender is the one derived by the protocol at the time of inclusion.
+//
+// There is a fast-path for transactions retrieved by TransactionByHash and
+// TransactionInBlock. Getting their sender address can be done without an RPC interaction.
+func (c *RateLimitedEthClient) TransactionSender(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (common.Address, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return common.Address{}, err
+	}
+	return c.client.TransactionSender(ctx, tx, block, index)
+}
+
+// TransactionCount returns the total number of transactions in the given block.
+func (c *RateLimitedEthClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return c.client.TransactionCount(ctx, blockHash)
+}
+
+// TransactionInBlock returns a single transaction at index in the given block.
+func (c *RateLimitedEthClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.TransactionInBlock(ctx, blockHash, index)
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+// Note that the receipt is not available for pending transactions.
+func (c *RateLimitedEthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.TransactionReceipt(ctx, txHash)
+}
+
+// SyncProgress retrieves the current progress of the sync algorithm. If there's
+// no sync currently running, it returns nil.
+func (c *RateLimitedEthClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.SyncProgress(ctx)
+}
+
+// SubscribeNewHead subscribes to notifications about the current blockchain head
+// on the given channel.
+func (c *RateLimitedEthClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.SubscribeNewHead(ctx, ch)
+}
+
+// NetworkID returns the network ID (also known as the chain ID) for this chain.
+func (c *RateLimitedEthClient) NetworkID(ctx context.Context) (*big.Int, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.NetworkID(ctx)
+}
+
+// BalanceAt returns the wei balance of the given account.
+// The block number can be nil, in which case the balance is taken from the latest known block.
+func (c *RateLimitedEthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.BalanceAt(ctx, account, blockNumber)
+}
+
+// StorageAt returns the value of key in the contract storage of the given account.
+// The block number can be nil, in which case the value is taken from the latest known block.
+func (c *RateLimitedEthClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.StorageAt(ctx, account, key, blockNumber)
+}
+
+// CodeAt returns the contract code of the given account.
+// The block number can be nil, in which case the code is taken from the latest known block.
+func (c *RateLimitedEthClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.CodeAt(ctx, account, blockNumber)
+}
+
+// NonceAt returns the account nonce of the given account.
+// The block number can be nil, in which case the nonce is taken from the latest known block.
+func (c *RateLimitedEthClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return c.client.NonceAt(ctx, account, blockNumber)
+}
+
+// FilterLogs executes a filter query.
+func (c *RateLimitedEthClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.FilterLogs(ctx, q)
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query.
+func (c *RateLimitedEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.SubscribeFilterLogs(ctx, q, ch)
+}
+
+// PendingBalanceAt returns the wei balance of the given account in the pending state.
+func (c *RateLimitedEthClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.PendingBalanceAt(ctx, account)
+}
+
+// PendingStorageAt returns the value of key in the contract storage of the given account in the pending state.
+func (c *RateLimitedEthClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.PendingStorageAt(ctx, account, key)
+}
+
+// PendingCodeAt returns the contract code of the given account in the pending state.
+func (c *RateLimitedEthClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.PendingCodeAt(ctx, account)
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+// This is the nonce that should be used for the next transaction.
+func (c *RateLimitedEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return c.client.PendingNonceAt(ctx, account)
+}
+
+// PendingTransactionCount returns the total number of transactions in the pending state.
+func (c *RateLimitedEthClient) PendingTransactionCount(ctx context.Context) (uint, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return c.client.PendingTransactionCount(ctx)
+}
+
+// CallContract executes a message call transaction, which is directly executed in the VM
+// of the node, but never mined into the blockchain.
+//
+// blockNumber selects the block height at which the call runs. It can be nil, in which
+// case the code is taken from the latest known block. Note that state from very old
+// blocks might not be available.
+func (c *RateLimitedEthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.CallContract(ctx, msg, blockNumber)
+}
+
+// PendingCallContract executes a message call transaction using the EVM.
+// The state seen by the contract call is the pending state.
+func (c *RateLimitedEthClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.PendingCallContract(ctx, msg)
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price to allow a timely
+// execution of a transaction.
+func (c *RateLimitedEthClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.SuggestGasPrice(ctx)
+}
+
+// SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
+// a timely execution of a transaction.
+func (c *RateLimitedEthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.SuggestGasTipCap(ctx)
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction based on
+// the current pending state of the backend blockchain. There is no guarantee that this is
+// the true gas limit requirement as other transactions may be added or removed by miners,
+// but it should provide a basis for setting a reasonable default.
+func (c *RateLimitedEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return c.client.EstimateGas(ctx, msg)
+}
+
+// SendTransaction injects a signed transaction into the pending pool for execution.
+//
+// If the transaction was a contract creation use the TransactionReceipt method to get the
+// contract address after the transaction has been mined.
+func (c *RateLimitedEthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.client.SendTransaction(ctx, tx)
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/payments/v3/client/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RateLimitedEthClient(t *testing.T) {
+	cl := &mocks.EtherClientMock{
+		PendingNonceAtFunc: func(ctx context.Context, account common.Address) (uint64, error) {
+			return 1, nil
+		},
+	}
+
+	t.Run("calls are delayed to match the rate", func(t *testing.T) {
+		limited := NewRateLimitedEthClient(cl, 20, 1)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			nonce, err := limited.Client().PendingNonceAt(context.Background(), common.Address{})
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(1), nonce)
+		}
+
+		// First call uses the burst, other two wait 50ms each.
+		assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*90)
+		assert.Len(t, cl.PendingNonceAtCalls(), 3)
+	})
+
+	t.Run("blocked call returns on context cancel", func(t *testing.T) {
+		limited := NewRateLimitedEthClient(cl, 0.1, 1)
+		before := len(cl.PendingNonceAtCalls())
+
+		_, err := limited.PendingNonceAt(context.Background(), common.Address{})
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+
+		_, err = limited.PendingNonceAt(ctx, common.Address{})
+		assert.Error(t, err)
+		assert.Len(t, cl.PendingNonceAtCalls(), before+1)
+	})
+}
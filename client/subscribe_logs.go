@@ -0,0 +1,219 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// logsBufferSize is the capacity of the channel logs are delivered on.
+const logsBufferSize = 128
+
+var errLogSubscriptionClosed = errors.New("log subscription closed")
+
+// SubscribeLogs subscribes to logs matching the given filter and delivers them on the returned channel.
+// Subscriptions are only supported by websocket and IPC connections.
+//
+// If the subscription breaks, the error is sent on the error channel and after retryInterval
+// the client is reconnected and subscribed again. Logs emitted while the subscription was down
+// are fetched starting from the last processed block, so none of them are missed or delivered
+// twice. Filters by block hash are fetched again as a whole instead. Errors are dropped if the
+// error channel is not being read.
+//
+// Both channels are closed once ctx is done.
+func (c *ReconnectableEthClient) SubscribeLogs(ctx context.Context, filter ethereum.FilterQuery, retryInterval time.Duration) (<-chan types.Log, <-chan error) {
+	logs := make(chan types.Log, logsBufferSize)
+	errs := make(chan error, 1)
+
+	report := func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+
+	go func() {
+		defer close(errs)
+		defer close(logs)
+
+		var cursor *logCursor
+		for {
+			var err error
+			cursor, err = c.streamLogs(ctx, filter, cursor, logs)
+			if ctx.Err() != nil {
+				return
+			}
+			report(err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+
+			if err := c.Reconnect(retryInterval); err != nil {
+				report(err)
+			}
+		}
+	}()
+
+	return logs, errs
+}
+
+// logCursor tracks which logs of a subscription were already processed.
+type logCursor struct {
+	// from is the first block which may hold logs that were not delivered yet.
+	from uint64
+	// last is the last delivered log, logs up to it are skipped.
+	last *types.Log
+}
+
+// streamLogs delivers logs to out until the subscription fails or ctx is done.
+// If cursor is given, logs after it which were missed are delivered first,
+// otherwise a new cursor is started at the current head.
+// It returns the cursor after the last processed log.
+func (c *ReconnectableEthClient) streamLogs(ctx context.Context, filter ethereum.FilterQuery, cursor *logCursor, out chan<- types.Log) (*logCursor, error) {
+	client := c.Client()
+
+	ch := make(chan types.Log, logsBufferSize)
+	sub, err := client.SubscribeFilterLogs(ctx, filter, ch)
+	if err != nil {
+		return cursor, err
+	}
+	defer sub.Unsubscribe()
+
+	deliver := func(l types.Log) error {
+		if cursor.last != nil && !l.Removed && !isLogAfter(l, *cursor.last) {
+			return nil
+		}
+
+		select {
+		case out <- l:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if !l.Removed {
+			cursor.last = &l
+			cursor.from = l.BlockNumber
+		}
+		return nil
+	}
+
+	if cursor == nil {
+		cursor, err = newLogCursor(ctx, client, filter)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		missed, head, err := fetchMissedLogs(ctx, client, filter, cursor.from)
+		if err != nil {
+			return cursor, err
+		}
+
+		for _, l := range missed {
+			if err := deliver(l); err != nil {
+				return cursor, err
+			}
+		}
+
+		// Everything up to the head was processed, later logs
+		// are delivered by the subscription.
+		if filter.BlockHash == nil && head+1 > cursor.from {
+			cursor.from = head + 1
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return cursor, ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = errLogSubscriptionClosed
+			}
+			return cursor, err
+		case l := <-ch:
+			if err := deliver(l); err != nil {
+				return cursor, err
+			}
+		}
+	}
+}
+
+// newLogCursor starts a cursor after the current head, as the subscription
+// only delivers logs of new blocks, or at the filter start if it is later.
+func newLogCursor(ctx context.Context, client EtherClient, filter ethereum.FilterQuery) (*logCursor, error) {
+	if filter.BlockHash != nil {
+		return &logCursor{}, nil
+	}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &logCursor{from: head + 1}
+	if filter.FromBlock != nil && filter.FromBlock.Sign() > 0 && filter.FromBlock.Uint64() > cursor.from {
+		cursor.from = filter.FromBlock.Uint64()
+	}
+	return cursor, nil
+}
+
+// fetchMissedLogs returns logs matching the filter from the given block up to
+// the current head, which is returned as well. Filters by block hash are
+// fetched as they are.
+func fetchMissedLogs(ctx context.Context, client EtherClient, filter ethereum.FilterQuery, from uint64) ([]types.Log, uint64, error) {
+	if filter.BlockHash != nil {
+		logs, err := client.FilterLogs(ctx, filter)
+		return logs, 0, err
+	}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if head < from {
+		return nil, head, nil
+	}
+
+	missedFilter := filter
+	missedFilter.FromBlock = new(big.Int).SetUint64(from)
+	missedFilter.ToBlock = new(big.Int).SetUint64(head)
+	if filter.ToBlock != nil && filter.ToBlock.Sign() >= 0 && filter.ToBlock.Cmp(missedFilter.ToBlock) < 0 {
+		missedFilter.ToBlock = filter.ToBlock
+	}
+
+	logs, err := client.FilterLogs(ctx, missedFilter)
+	return logs, head, err
+}
+
+// isLogAfter returns true if log l was emitted after log last.
+func isLogAfter(l, last types.Log) bool {
+	if l.BlockNumber != last.BlockNumber {
+		return l.BlockNumber > last.BlockNumber
+	}
+
+	return l.Index > last.Index
+}
@@ -0,0 +1,324 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLogsService serves eth_subscribe("logs"), eth_getLogs and eth_blockNumber
+// from a fixed list of logs. The head is the block of the latest log.
+type fakeLogsService struct {
+	mu          sync.Mutex
+	logs        []types.Log
+	head        uint64
+	subscribers map[rpc.ID]*rpc.Notifier
+	getLogsFrom []string
+	accepted    int
+}
+
+func (s *fakeLogsService) BlockNumber() hexutil.Uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return hexutil.Uint64(s.head)
+}
+
+func (s *fakeLogsService) Logs(ctx context.Context, crit map[string]interface{}) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+
+	s.mu.Lock()
+	s.subscribers[sub.ID] = notifier
+	s.mu.Unlock()
+
+	go func() {
+		<-sub.Err()
+		s.mu.Lock()
+		delete(s.subscribers, sub.ID)
+		s.mu.Unlock()
+	}()
+
+	return sub, nil
+}
+
+func (s *fakeLogsService) GetLogs(ctx context.Context, crit map[string]interface{}) ([]types.Log, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hash, ok := crit["blockHash"].(string); ok {
+		s.getLogsFrom = append(s.getLogsFrom, hash)
+		res := []types.Log{}
+		for _, l := range s.logs {
+			if l.BlockHash == common.HexToHash(hash) {
+				res = append(res, l)
+			}
+		}
+		return res, nil
+	}
+
+	from, _ := crit["fromBlock"].(string)
+	s.getLogsFrom = append(s.getLogsFrom, from)
+
+	fromBlock, _ := hexutil.DecodeUint64(from)
+	to, _ := crit["toBlock"].(string)
+	toBlock, err := hexutil.DecodeUint64(to)
+	if err != nil {
+		toBlock = s.head
+	}
+	res := []types.Log{}
+	for _, l := range s.logs {
+		if l.BlockNumber >= fromBlock && l.BlockNumber <= toBlock {
+			res = append(res, l)
+		}
+	}
+	return res, nil
+}
+
+// emit stores the log and, if notify is set, pushes it to all subscribers.
+func (s *fakeLogsService) emit(l types.Log, notify bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logs = append(s.logs, l)
+	if l.BlockNumber > s.head {
+		s.head = l.BlockNumber
+	}
+	if !notify {
+		return
+	}
+	for id, n := range s.subscribers {
+		n.Notify(id, l)
+	}
+}
+
+// acceptedCount returns the number of websocket connections accepted so far.
+func (s *fakeLogsService) acceptedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.accepted
+}
+
+func (s *fakeLogsService) subscriberCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.subscribers)
+}
+
+// hijackTracker remembers websocket connections so they can be dropped.
+type hijackTracker struct {
+	http.ResponseWriter
+	track func(net.Conn)
+}
+
+func (h hijackTracker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := h.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		h.track(conn)
+	}
+	return conn, rw, err
+}
+
+func newFakeLogsNode(t *testing.T) (*fakeLogsService, string, func()) {
+	service := &fakeLogsService{subscribers: make(map[rpc.ID]*rpc.Notifier)}
+	server := rpc.NewServer()
+	assert.NoError(t, server.RegisterName("eth", service))
+
+	var mu sync.Mutex
+	var conns []net.Conn
+	ws := server.WebsocketHandler([]string{"*"})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws.ServeHTTP(hijackTracker{ResponseWriter: w, track: func(c net.Conn) {
+			mu.Lock()
+			conns = append(conns, c)
+			mu.Unlock()
+
+			service.mu.Lock()
+			service.accepted++
+			service.mu.Unlock()
+		}}, r)
+	}))
+	t.Cleanup(func() {
+		srv.Close()
+		server.Stop()
+	})
+
+	dropConnections := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, c := range conns {
+			c.Close()
+		}
+		conns = nil
+	}
+
+	return service, "ws" + strings.TrimPrefix(srv.URL, "http"), dropConnections
+}
+
+func testLog(block uint64, index uint) types.Log {
+	return types.Log{
+		BlockNumber: block,
+		Index:       index,
+		Topics:      []common.Hash{},
+		Data:        []byte{},
+	}
+}
+
+func receiveLog(t *testing.T, logs <-chan types.Log) types.Log {
+	select {
+	case l := <-logs:
+		return l
+	case <-time.After(time.Second * 2):
+		t.Fatal("log not received")
+	}
+	return types.Log{}
+}
+
+func waitSubscriptionError(t *testing.T, errs <-chan error) {
+	select {
+	case err := <-errs:
+		assert.Error(t, err)
+	case <-time.After(time.Second * 2):
+		t.Fatal("subscription error not reported")
+	}
+}
+
+func TestReconnectableEthClientSubscribeLogs(t *testing.T) {
+	service, url, dropConnections := newFakeLogsNode(t)
+
+	client, err := NewReconnectableEthClient(url, time.Second)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logs, errs := client.SubscribeLogs(ctx, ethereum.FilterQuery{}, time.Millisecond*10)
+
+	receive := func() types.Log {
+		return receiveLog(t, logs)
+	}
+
+	assert.Eventually(t, func() bool { return service.subscriberCount() == 1 }, time.Second, time.Millisecond*5)
+	service.emit(testLog(1, 0), true)
+	service.emit(testLog(2, 0), true)
+	assert.Equal(t, uint64(1), receive().BlockNumber)
+	assert.Equal(t, uint64(2), receive().BlockNumber)
+
+	// Never pushed to the subscriber, must be fetched after resubscribing.
+	service.emit(testLog(2, 1), false)
+	service.emit(testLog(3, 0), false)
+
+	dropConnections()
+	waitSubscriptionError(t, errs)
+
+	l := receive()
+	assert.Equal(t, uint64(2), l.BlockNumber)
+	assert.Equal(t, uint(1), l.Index)
+	assert.Equal(t, uint64(3), receive().BlockNumber)
+
+	assert.Eventually(t, func() bool { return service.subscriberCount() == 1 }, time.Second, time.Millisecond*5)
+	service.emit(testLog(4, 0), true)
+	assert.Equal(t, uint64(4), receive().BlockNumber)
+
+	service.mu.Lock()
+	assert.Equal(t, []string{"0x2"}, service.getLogsFrom)
+	service.mu.Unlock()
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		select {
+		case _, ok := <-logs:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond*5)
+}
+
+func TestReconnectableEthClientSubscribeLogsBackfill(t *testing.T) {
+	t.Run("subscription breaks before the first log", func(t *testing.T) {
+		service, url, dropConnections := newFakeLogsNode(t)
+		// Logs before the subscription started are not delivered.
+		service.emit(testLog(5, 0), false)
+
+		client, err := NewReconnectableEthClient(url, time.Second)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		logs, errs := client.SubscribeLogs(ctx, ethereum.FilterQuery{}, time.Millisecond*10)
+		assert.Eventually(t, func() bool { return service.subscriberCount() == 1 }, time.Second, time.Millisecond*5)
+
+		// Never pushed to the subscriber, must be fetched after resubscribing.
+		service.emit(testLog(6, 0), false)
+		dropConnections()
+		waitSubscriptionError(t, errs)
+
+		assert.Equal(t, uint64(6), receiveLog(t, logs).BlockNumber)
+
+		service.mu.Lock()
+		assert.Equal(t, []string{"0x6"}, service.getLogsFrom)
+		service.mu.Unlock()
+	})
+
+	t.Run("block hash filter is fetched again", func(t *testing.T) {
+		service, url, dropConnections := newFakeLogsNode(t)
+		hash := common.HexToHash("0x01")
+
+		client, err := NewReconnectableEthClient(url, time.Second)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		logs, errs := client.SubscribeLogs(ctx, ethereum.FilterQuery{BlockHash: &hash}, time.Millisecond*10)
+		assert.Eventually(t, func() bool { return service.subscriberCount() == 1 }, time.Second, time.Millisecond*5)
+
+		first := testLog(1, 0)
+		first.BlockHash = hash
+		second := testLog(1, 1)
+		second.BlockHash = hash
+		service.emit(first, true)
+		assert.Equal(t, uint(0), receiveLog(t, logs).Index)
+
+		service.emit(second, false)
+		dropConnections()
+		waitSubscriptionError(t, errs)
+
+		// Logs delivered before are skipped.
+		assert.Equal(t, uint(1), receiveLog(t, logs).Index)
+
+		service.mu.Lock()
+		assert.Equal(t, []string{hash.Hex()}, service.getLogsFrom)
+		service.mu.Unlock()
+	})
+}
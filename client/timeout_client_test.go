@@ -0,0 +1,93 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/payments/v3/client/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TimeoutEthClient(t *testing.T) {
+	deadlineIn := func(ctx context.Context) time.Duration {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			return 0
+		}
+		return time.Until(deadline).Round(time.Second)
+	}
+
+	var got time.Duration
+	cl := &mocks.EtherClientMock{
+		PendingNonceAtFunc: func(ctx context.Context, account common.Address) (uint64, error) {
+			got = deadlineIn(ctx)
+			return 1, nil
+		},
+		BlockNumberFunc: func(ctx context.Context) (uint64, error) {
+			got = deadlineIn(ctx)
+			return 1, nil
+		},
+	}
+
+	t.Run("call kind timeout is used", func(t *testing.T) {
+		c := NewTimeoutEthClient(cl, TimeoutConfig{
+			DefaultTimeout: time.Second * 5,
+			NonceTimeout:   time.Second,
+			BlockTimeout:   time.Second * 15,
+		})
+
+		_, err := c.Client().PendingNonceAt(context.Background(), common.Address{})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, got)
+
+		_, err = c.Client().BlockNumber(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second*15, got)
+	})
+
+	t.Run("default timeout is used as fallback", func(t *testing.T) {
+		c := NewTimeoutEthClient(cl, TimeoutConfig{DefaultTimeout: time.Second * 5})
+
+		_, err := c.PendingNonceAt(context.Background(), common.Address{})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second*5, got)
+	})
+
+	t.Run("earlier parent deadline is kept", func(t *testing.T) {
+		c := NewTimeoutEthClient(cl, TimeoutConfig{NonceTimeout: time.Second * 10})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+		defer cancel()
+
+		_, err := c.PendingNonceAt(ctx, common.Address{})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second*2, got)
+	})
+
+	t.Run("no timeouts configured", func(t *testing.T) {
+		c := NewTimeoutEthClient(cl, TimeoutConfig{})
+
+		_, err := c.PendingNonceAt(context.Background(), common.Address{})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), got)
+	})
+}
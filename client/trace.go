@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TraceIDHeader is the HTTP header used to pass trace IDs to the node.
+const TraceIDHeader = "X-Trace-Id"
+
+type traceIDKey struct{}
+
+// WithTraceID returns a context carrying the given trace ID.
+// Calls made with it through any of the ethereum clients send the ID
+// to HTTP nodes in the `TraceIDHeader` header, so that node side logs
+// can be correlated with application traces.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, traceIDKey{}, id)
+	return rpc.NewContextWithHeaders(ctx, http.Header{TraceIDHeader: []string{id}})
+}
+
+// TraceIDFromContext returns the trace ID set with `WithTraceID`, if any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(traceIDKey{}).(string)
+	return id, ok
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTraceID(t *testing.T) {
+	node := newTraceNode()
+	defer node.Close()
+
+	client, err := NewReconnectableEthClient(node.URL, time.Second)
+	assert.NoError(t, err)
+
+	_, ok := TraceIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithTraceID(context.Background(), "trace-1")
+	id, ok := TraceIDFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "trace-1", id)
+
+	_, err = client.Client().BlockNumber(ctx)
+	assert.NoError(t, err)
+	_, err = client.Client().BlockNumber(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"trace-1", ""}, node.received())
+}
+
+func TestWithTraceIDEthMultiClient(t *testing.T) {
+	node := newTraceNode()
+	defer node.Close()
+
+	client, err := NewReconnectableEthClient(node.URL, time.Second)
+	assert.NoError(t, err)
+
+	multi, err := NewEthMultiClient(time.Second, []AddressableEthClientGetter{client})
+	assert.NoError(t, err)
+
+	_, err = multi.BlockNumber(WithTraceID(context.Background(), "trace-2"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"trace-2"}, node.received())
+}
+
+// traceNode is a JSON-RPC node which records the trace ID header of every
+// request it receives.
+type traceNode struct {
+	*httptest.Server
+	mu       sync.Mutex
+	traceIDs []string
+}
+
+func newTraceNode() *traceNode {
+	n := &traceNode{}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.mu.Lock()
+		n.traceIDs = append(n.traceIDs, r.Header.Get(TraceIDHeader))
+		n.mu.Unlock()
+
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  "0x1",
+		})
+	}))
+	return n
+}
+
+func (n *traceNode) received() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.traceIDs...)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// replacementSearchDepth is the amount of blocks that will be looked
+// through when searching for a transaction which replaced the awaited one.
+const replacementSearchDepth = 128
+
+// ErrReplacementNotFound is returned when the nonce of an awaited transaction
+// was used by a different transaction, but it could not be located.
+var ErrReplacementNotFound = errors.New("transaction was replaced, but replacement could not be found")
+
+// WaitMined polls the blockchain every interval until the given transaction
+// is included in a block and returns its receipt.
+//
+// If a different transaction with the same sender and nonce
+// gets included instead (e.g. one with a bumped gas price),
+// receipt of that replacement transaction is returned.
+//
+// Polling stops with ctx.Err() once the given context is done.
+func WaitMined(ctx context.Context, c EtherClient, tx *types.Transaction, interval time.Duration) (*types.Receipt, error) {
+	signer := types.LatestSignerForChainID(tx.ChainId())
+	sender, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover transaction sender: %w", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := c.TransactionReceipt(ctx, tx.Hash())
+		if err == nil && receipt != nil {
+			return receipt, nil
+		}
+
+		nonce, err := c.NonceAt(ctx, sender, nil)
+		if err == nil && nonce > tx.Nonce() {
+			return findReplacementReceipt(ctx, c, signer, sender, tx.Nonce())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitMined waits for the transaction on a given chain to be mined.
+func (mbc *MultichainBlockchainClient) WaitMined(ctx context.Context, chainID int64, tx *types.Transaction, interval time.Duration) (*types.Receipt, error) {
+	bc, err := mbc.GetClientByChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return WaitMined(ctx, bc.Client(), tx, interval)
+}
+
+func findReplacementReceipt(ctx context.Context, c EtherClient, signer types.Signer, sender common.Address, nonce uint64) (*types.Receipt, error) {
+	head, err := c.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := uint64(0); i < replacementSearchDepth && i <= head; i++ {
+		block, err := c.BlockByNumber(ctx, new(big.Int).SetUint64(head-i))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, btx := range block.Transactions() {
+			if btx.Nonce() != nonce {
+				continue
+			}
+
+			from, err := types.Sender(signer, btx)
+			if err != nil || from != sender {
+				continue
+			}
+
+			return c.TransactionReceipt(ctx, btx.Hash())
+		}
+	}
+
+	return nil, ErrReplacementNotFound
+}
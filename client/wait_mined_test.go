@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2021 The "MysteriumNetwork/payments" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mysteriumnetwork/payments/v3/client/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WaitMined(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+
+	chainID := big.NewInt(1)
+	signTx := func(nonce uint64, tip int64) *types.Transaction {
+		to := common.HexToAddress("0x1")
+		tx, err := types.SignNewTx(pk, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(tip + 100),
+			Gas:       21000,
+			To:        &to,
+		})
+		assert.NoError(t, err)
+		return tx
+	}
+
+	t.Run("returns receipt once mined", func(t *testing.T) {
+		tx := signTx(5, 1)
+
+		var calls int32
+		cl := &mocks.EtherClientMock{
+			TransactionReceiptFunc: func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+				if atomic.AddInt32(&calls, 1) < 3 {
+					return nil, ethereum.NotFound
+				}
+				return &types.Receipt{TxHash: txHash}, nil
+			},
+			NonceAtFunc: func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+				return 5, nil
+			},
+		}
+
+		receipt, err := WaitMined(context.Background(), cl, tx, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, tx.Hash(), receipt.TxHash)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("returns replacement receipt", func(t *testing.T) {
+		tx := signTx(5, 1)
+		replacement := signTx(5, 2)
+		other := signTx(4, 1)
+
+		cl := &mocks.EtherClientMock{
+			TransactionReceiptFunc: func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+				if txHash == replacement.Hash() {
+					return &types.Receipt{TxHash: txHash}, nil
+				}
+				return nil, ethereum.NotFound
+			},
+			NonceAtFunc: func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+				return 6, nil
+			},
+			BlockNumberFunc: func(ctx context.Context) (uint64, error) {
+				return 10, nil
+			},
+			BlockByNumberFunc: func(ctx context.Context, number *big.Int) (*types.Block, error) {
+				header := &types.Header{Number: number}
+				if number.Int64() == 9 {
+					return types.NewBlockWithHeader(header).WithBody(types.Transactions{other, replacement}, nil), nil
+				}
+				return types.NewBlockWithHeader(header), nil
+			},
+		}
+
+		receipt, err := WaitMined(context.Background(), cl, tx, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, replacement.Hash(), receipt.TxHash)
+	})
+
+	t.Run("replacement not found", func(t *testing.T) {
+		tx := signTx(5, 1)
+
+		cl := &mocks.EtherClientMock{
+			TransactionReceiptFunc: func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+				return nil, ethereum.NotFound
+			},
+			NonceAtFunc: func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+				return 6, nil
+			},
+			BlockNumberFunc: func(ctx context.Context) (uint64, error) {
+				return 2, nil
+			},
+			BlockByNumberFunc: func(ctx context.Context, number *big.Int) (*types.Block, error) {
+				return types.NewBlockWithHeader(&types.Header{Number: number}), nil
+			},
+		}
+
+		_, err := WaitMined(context.Background(), cl, tx, time.Millisecond)
+		assert.ErrorIs(t, err, ErrReplacementNotFound)
+	})
+
+	t.Run("stops on context cancel", func(t *testing.T) {
+		tx := signTx(5, 1)
+
+		cl := &mocks.EtherClientMock{
+			TransactionReceiptFunc: func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+				return nil, ethereum.NotFound
+			},
+			NonceAtFunc: func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+				return 5, nil
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+		defer cancel()
+
+		_, err := WaitMined(ctx, cl, tx, time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,96 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// abiWordSize is the size of a single ABI encoded static value.
+const abiWordSize = 32
+
+// EncodeUint256 ABI encodes the given number as uint256.
+// Nil is encoded as zero. Numbers which do not fit into 256 bits are
+// truncated to their lowest 256 bits and negative numbers are encoded
+// in two's complement, the same way Solidity wraps them.
+func EncodeUint256(n *big.Int) []byte {
+	if n == nil {
+		return make([]byte, abiWordSize)
+	}
+
+	return math.U256Bytes(new(big.Int).Set(n))
+}
+
+// EncodeAddress ABI encodes the given address, left padding it to 32 bytes.
+func EncodeAddress(a common.Address) []byte {
+	return Pad(a.Bytes(), abiWordSize)
+}
+
+// EncodeBytes32 ABI encodes the given bytes32 value.
+func EncodeBytes32(b [32]byte) []byte {
+	res := make([]byte, abiWordSize)
+	copy(res, b[:])
+	return res
+}
+
+// DecodeUint256 decodes an ABI encoded uint256.
+func DecodeUint256(b []byte) (*big.Int, error) {
+	if err := checkABIWord(b); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// DecodeAddress decodes an ABI encoded address.
+// Words with non zero bytes in their padding are rejected.
+func DecodeAddress(b []byte) (common.Address, error) {
+	if err := checkABIWord(b); err != nil {
+		return common.Address{}, err
+	}
+
+	padding := abiWordSize - common.AddressLength
+	if !bytes.Equal(b[:padding], make([]byte, padding)) {
+		return common.Address{}, errors.New("address padding must be zero")
+	}
+
+	return common.BytesToAddress(b[padding:]), nil
+}
+
+// DecodeBytes32 decodes an ABI encoded bytes32 value.
+func DecodeBytes32(b []byte) ([32]byte, error) {
+	var res [32]byte
+	if err := checkABIWord(b); err != nil {
+		return res, err
+	}
+
+	copy(res[:], b)
+	return res, nil
+}
+
+func checkABIWord(b []byte) error {
+	if len(b) != abiWordSize {
+		return fmt.Errorf("expected %d bytes, got %d", abiWordSize, len(b))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestABIHelpers(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	t.Run("uint256", func(t *testing.T) {
+		uint256Ty, err := abi.NewType("uint256", "", nil)
+		assert.NoError(t, err)
+		args := abi.Arguments{{Type: uint256Ty}}
+
+		for _, n := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(1234567890), maxUint256} {
+			expected, err := args.Pack(n)
+			assert.NoError(t, err)
+
+			encoded := EncodeUint256(n)
+			assert.Equal(t, expected, encoded, n.String())
+
+			decoded, err := DecodeUint256(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, n.Cmp(decoded), n.String())
+		}
+
+		assert.Equal(t, make([]byte, 32), EncodeUint256(nil))
+
+		// Oversized numbers keep their lowest 256 bits.
+		oversized := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(5))
+		assert.Equal(t, EncodeUint256(big.NewInt(5)), EncodeUint256(oversized))
+		assert.Equal(t, EncodeUint256(maxUint256), EncodeUint256(big.NewInt(-1)))
+
+		// Input must not be modified.
+		n := big.NewInt(-1)
+		EncodeUint256(n)
+		assert.Equal(t, "-1", n.String())
+
+		_, err = DecodeUint256(make([]byte, 31))
+		assert.Error(t, err)
+	})
+
+	t.Run("address", func(t *testing.T) {
+		addr := common.HexToAddress("0x1be7B0F285d04701F27682F591a60417C47D095a")
+		encoded := EncodeAddress(addr)
+		assert.Equal(t, strings.ToLower("0000000000000000000000001be7b0f285d04701f27682f591a60417c47d095a"), common.Bytes2Hex(encoded))
+
+		decoded, err := DecodeAddress(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, addr, decoded)
+
+		dirty := EncodeAddress(addr)
+		dirty[0] = 1
+		_, err = DecodeAddress(dirty)
+		assert.Error(t, err)
+
+		_, err = DecodeAddress(addr.Bytes())
+		assert.Error(t, err)
+	})
+
+	t.Run("bytes32", func(t *testing.T) {
+		var b [32]byte
+		copy(b[:], "hello")
+		encoded := EncodeBytes32(b)
+		assert.Equal(t, PadRight([]byte("hello"), 32), encoded)
+
+		decoded, err := DecodeBytes32(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, b, decoded)
+
+		_, err = DecodeBytes32([]byte("hello"))
+		assert.Error(t, err)
+	})
+}
@@ -61,9 +61,9 @@ func CreateBeneficiaryRequest(chainID int64, identity, registry, beneficiary str
 func NewBeneficiaryRequest(chainID int64, identity, registry, beneficiary string, nonce *big.Int, signature string) (*SetBeneficiaryRequest, error) {
 	return &SetBeneficiaryRequest{
 		ChainID:     chainID,
-		Identity:    ensureNoPrefix(strings.ToLower(identity)),
-		Registry:    ensureNoPrefix(strings.ToLower(registry)),
-		Beneficiary: ensureNoPrefix(strings.ToLower(beneficiary)),
+		Identity:    EnsureNoPrefix(strings.ToLower(identity)),
+		Registry:    EnsureNoPrefix(strings.ToLower(registry)),
+		Beneficiary: EnsureNoPrefix(strings.ToLower(beneficiary)),
 		Nonce:       nonce,
 		Signature:   signature,
 	}, nil
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mysteriumnetwork/payments/v3/crypto/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,7 +59,7 @@ func TestBeneficiary(t *testing.T) {
 		})
 
 		t.Run("recover signer", func(t *testing.T) {
-			pk, err := crypto.GenerateKey()
+			pk, err := testutil.DeterministicKey(4)
 			require.NoError(t, err)
 			publicKeyECDSA, ok := pk.Public().(*ecdsa.PublicKey)
 			require.True(t, ok)
@@ -25,6 +25,16 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// Keccak256 calculates the Keccak256 hash of the concatenation of the given data.
+func Keccak256(data ...[]byte) []byte {
+	return crypto.Keccak256(data...)
+}
+
+// Keccak256Hash calculates the Keccak256 hash of the concatenation of the given data as a common.Hash.
+func Keccak256Hash(data ...[]byte) common.Hash {
+	return crypto.Keccak256Hash(data...)
+}
+
 // RecoverAddress recovers the address from message and signature
 func RecoverAddress(message []byte, signature []byte) (common.Address, error) {
 	publicKey, err := crypto.Ecrecover(crypto.Keccak256(message), signature)
@@ -39,6 +49,43 @@ func RecoverAddress(message []byte, signature []byte) (common.Address, error) {
 	return recoveredAddress, nil
 }
 
+// VerifySignature checks whether the given signature over data was created by signerAddress.
+// Data is hashed the same way as in `RecoverAddress`. Both 65 byte signatures, with V set
+// either to 0/1 or 27/28, and 64 byte compact signatures as described in EIP-2098 are accepted.
+func VerifySignature(signerAddress common.Address, data []byte, signature []byte) (bool, error) {
+	sig, err := expandSignature(signature)
+	if err != nil {
+		return false, err
+	}
+
+	pubKey, err := crypto.SigToPub(crypto.Keccak256(data), sig)
+	if err != nil {
+		return false, err
+	}
+
+	return crypto.PubkeyToAddress(*pubKey) == signerAddress, nil
+}
+
+// expandSignature returns a copy of the signature in the 65 byte [R || S || V]
+// format with V normalized to either 0 or 1.
+func expandSignature(signature []byte) ([]byte, error) {
+	switch len(signature) {
+	case 65:
+		sig := make([]byte, 65)
+		copy(sig, signature)
+		return sig, ReformatSignatureVForRecovery(sig)
+	case 64:
+		// Compact signature stores V in the highest bit of S.
+		sig := make([]byte, 65)
+		copy(sig, signature)
+		sig[64] = sig[32] >> 7
+		sig[32] &= 0x7f
+		return sig, nil
+	default:
+		return nil, errors.New("the signature must be 64 or 65 bytes long")
+	}
+}
+
 // GetProxyCode generates bytecode of minimal proxy contract (EIP 1167)
 func GetProxyCode(destinationAddress string) ([]byte, error) {
 	return hex.DecodeString("3d602d80600a3d3981f3363d3d373d3d3d363d73" + destinationAddress + "5af43d82803e903d91602b57fd5bf3")
@@ -50,9 +97,9 @@ func deriveCreate2Address(salt string, msgSender string, implementation string)
 		return "", errors.New("msgSender and implementation have to be hex addresses")
 	}
 
-	bytecode, _ := GetProxyCode(ensureNoPrefix(implementation))
+	bytecode, _ := GetProxyCode(EnsureNoPrefix(implementation))
 
-	input, _ := hex.DecodeString("ff" + ensureNoPrefix(msgSender) + ensureNoPrefix(salt) + common.Bytes2Hex(crypto.Keccak256(bytecode)))
+	input, _ := hex.DecodeString("ff" + EnsureNoPrefix(msgSender) + EnsureNoPrefix(salt) + common.Bytes2Hex(crypto.Keccak256(bytecode)))
 	return "0x" + common.Bytes2Hex(crypto.Keccak256(input))[24:], nil
 }
 
@@ -62,7 +109,7 @@ func GenerateChannelAddress(identity, hermes, registry, channelImplementation st
 		return "", errors.New("given identity, registry and channelImplementation params have to be hex addresses")
 	}
 
-	saltBytes, err := hex.DecodeString(ensureNoPrefix(identity) + ensureNoPrefix(hermes))
+	saltBytes, err := hex.DecodeString(EnsureNoPrefix(identity) + EnsureNoPrefix(hermes))
 	if err != nil {
 		return "", err
 	}
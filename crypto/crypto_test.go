@@ -20,6 +20,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,3 +80,64 @@ func TestGenerateHermesAddress(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedHermesAddress, hermesAddress)
 }
+
+func TestVerifySignature(t *testing.T) {
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("verify")))
+	assert.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	data := []byte("promise")
+
+	sig, err := crypto.Sign(crypto.Keccak256(data), key)
+	assert.NoError(t, err)
+
+	t.Run("verifies signature with V as 0 or 1", func(t *testing.T) {
+		ok, err := VerifySignature(signer, data, sig)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("verifies signature with V as 27 or 28", func(t *testing.T) {
+		bcSig := append([]byte{}, sig...)
+		assert.NoError(t, ReformatSignatureVForBC(bcSig))
+
+		ok, err := VerifySignature(signer, data, bcSig)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, sig[64]+27, bcSig[64], "signature must not be modified")
+	})
+
+	t.Run("verifies compact signature", func(t *testing.T) {
+		compact := append([]byte{}, sig[:64]...)
+		compact[32] |= sig[64] << 7
+
+		ok, err := VerifySignature(signer, data, compact)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("rejects other signer", func(t *testing.T) {
+		ok, err := VerifySignature(common.HexToAddress("0x1"), data, sig)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects other data", func(t *testing.T) {
+		ok, err := VerifySignature(signer, []byte("other"), sig)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("fails on invalid signature length", func(t *testing.T) {
+		_, err := VerifySignature(signer, data, sig[:10])
+		assert.Error(t, err)
+	})
+}
+
+func TestKeccak256(t *testing.T) {
+	// Hash of an empty input is a well known constant.
+	assert.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", common.Bytes2Hex(Keccak256()))
+	assert.Equal(t, common.HexToHash("0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"), Keccak256Hash())
+
+	assert.Equal(t, Keccak256([]byte("hello world")), Keccak256([]byte("hello"), []byte(" "), []byte("world")))
+	assert.Equal(t, common.BytesToHash(Keccak256([]byte("hello"))), Keccak256Hash([]byte("hello")))
+}
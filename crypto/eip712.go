@@ -0,0 +1,135 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const eip712DomainType = "EIP712Domain"
+
+// EIP712Domain is the domain of EIP-712 typed data.
+type EIP712Domain = apitypes.TypedDataDomain
+
+// EIP712Types holds EIP-712 type definitions keyed by type name.
+type EIP712Types = apitypes.Types
+
+// TypedDataHash computes the EIP-712 hash of the given message, ready to be signed:
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)).
+//
+// The primary type of the message is the only type in types which is not
+// referenced by any other type. Definition of the EIP712Domain type can be
+// omitted, in which case it is built from the fields set in the domain.
+func TypedDataHash(domain EIP712Domain, message map[string]interface{}, types EIP712Types) ([]byte, error) {
+	primaryType, err := primaryEIP712Type(types)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := types[eip712DomainType]; !ok {
+		withDomain := make(EIP712Types, len(types)+1)
+		for name, fields := range types {
+			withDomain[name] = fields
+		}
+		withDomain[eip712DomainType] = eip712DomainFields(domain)
+		types = withDomain
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(apitypes.TypedData{
+		Types:       types,
+		PrimaryType: primaryType,
+		Domain:      domain,
+		Message:     message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not hash typed data: %w", err)
+	}
+
+	return hash, nil
+}
+
+// SignTypedData signs the given EIP-712 hash, as returned by `TypedDataHash`.
+// The returned signature has V set to 27 or 28 as expected by contracts.
+func SignTypedData(privateKey *ecdsa.PrivateKey, hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, errors.New("the hash must be 32 bytes long")
+	}
+
+	signature, err := crypto.Sign(hash, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ReformatSignatureVForBC(signature); err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+func primaryEIP712Type(types EIP712Types) (string, error) {
+	referenced := make(map[string]bool)
+	for _, fields := range types {
+		for _, field := range fields {
+			// Strip array suffixes like Person[] or Person[2][].
+			name, _, _ := strings.Cut(field.Type, "[")
+			referenced[name] = true
+		}
+	}
+
+	primary := ""
+	for name := range types {
+		if name == eip712DomainType || referenced[name] {
+			continue
+		}
+		if primary != "" {
+			return "", fmt.Errorf("could not determine primary type: both %q and %q are not referenced by other types", primary, name)
+		}
+		primary = name
+	}
+
+	if primary == "" {
+		return "", errors.New("could not determine primary type: no types given")
+	}
+	return primary, nil
+}
+
+func eip712DomainFields(domain EIP712Domain) []apitypes.Type {
+	var fields []apitypes.Type
+	if domain.Name != "" {
+		fields = append(fields, apitypes.Type{Name: "name", Type: "string"})
+	}
+	if domain.Version != "" {
+		fields = append(fields, apitypes.Type{Name: "version", Type: "string"})
+	}
+	if domain.ChainId != nil {
+		fields = append(fields, apitypes.Type{Name: "chainId", Type: "uint256"})
+	}
+	if domain.VerifyingContract != "" {
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	if domain.Salt != "" {
+		fields = append(fields, apitypes.Type{Name: "salt", Type: "bytes32"})
+	}
+	return fields
+}
@@ -0,0 +1,145 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedDataHash(t *testing.T) {
+	// Example taken from the EIP-712 specification.
+	domain := EIP712Domain{
+		Name:              "Ether Mail",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+	}
+	types := EIP712Types{
+		"Person": {
+			{Name: "name", Type: "string"},
+			{Name: "wallet", Type: "address"},
+		},
+		"Mail": {
+			{Name: "from", Type: "Person"},
+			{Name: "to", Type: "Person"},
+			{Name: "contents", Type: "string"},
+		},
+	}
+	message := map[string]interface{}{
+		"from": map[string]interface{}{
+			"name":   "Cow",
+			"wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+		},
+		"to": map[string]interface{}{
+			"name":   "Bob",
+			"wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+		},
+		"contents": "Hello, Bob!",
+	}
+	expectedHash := "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"
+
+	t.Run("hashes message", func(t *testing.T) {
+		hash, err := TypedDataHash(domain, message, types)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedHash, hexutil.Encode(hash))
+	})
+
+	t.Run("uses given domain type", func(t *testing.T) {
+		withDomain := EIP712Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Person": types["Person"],
+			"Mail":   types["Mail"],
+		}
+		hash, err := TypedDataHash(domain, message, withDomain)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedHash, hexutil.Encode(hash))
+	})
+
+	t.Run("handles type names ending with digits and arrays", func(t *testing.T) {
+		digitTypes := EIP712Types{
+			"Person2": types["Person"],
+			"Mail": {
+				{Name: "from", Type: "Person2"},
+				{Name: "to", Type: "Person2[]"},
+				{Name: "contents", Type: "string"},
+			},
+		}
+		digitMessage := map[string]interface{}{
+			"from":     message["from"],
+			"to":       []interface{}{message["to"]},
+			"contents": message["contents"],
+		}
+
+		_, err := TypedDataHash(domain, digitMessage, digitTypes)
+		assert.NoError(t, err)
+
+		primary, err := primaryEIP712Type(digitTypes)
+		assert.NoError(t, err)
+		assert.Equal(t, "Mail", primary)
+	})
+
+	t.Run("fails on ambiguous primary type", func(t *testing.T) {
+		ambiguous := EIP712Types{
+			"Mail":  types["Mail"],
+			"Other": []apitypes.Type{{Name: "value", Type: "uint256"}},
+		}
+		_, err := TypedDataHash(domain, message, ambiguous)
+		assert.Error(t, err)
+	})
+
+	t.Run("signs hash", func(t *testing.T) {
+		hash, err := TypedDataHash(domain, message, types)
+		assert.NoError(t, err)
+
+		key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+		assert.NoError(t, err)
+
+		sig, err := SignTypedData(key, hash)
+		assert.NoError(t, err)
+		assert.Len(t, sig, 65)
+		assert.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d", hexutil.Encode(sig[:32]))
+		assert.Equal(t, "0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562", hexutil.Encode(sig[32:64]))
+		assert.Equal(t, byte(28), sig[64])
+
+		recoverable := append([]byte{}, sig...)
+		assert.NoError(t, ReformatSignatureVForRecovery(recoverable))
+		pub, err := crypto.SigToPub(hash, recoverable)
+		assert.NoError(t, err)
+		assert.Equal(t, common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), crypto.PubkeyToAddress(*pub))
+	})
+
+	t.Run("fails on invalid hash", func(t *testing.T) {
+		key, err := crypto.ToECDSA(big.NewInt(1).FillBytes(make([]byte, 32)))
+		assert.NoError(t, err)
+
+		_, err = SignTypedData(key, []byte{1, 2, 3})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,97 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// EncodePacked encodes the given values the same way Solidity's abi.encodePacked does.
+//
+// Go types map to Solidity types as follows:
+//   - bool is bool, encoded as a single byte.
+//   - uint8..uint64 and int8..int64 are the integers of the same size.
+//   - *big.Int is uint256 or int256, negative numbers are encoded in two's complement.
+//   - common.Address is address.
+//   - byte arrays of length 1 to 32, like common.Hash, are bytes1..bytes32.
+//   - []byte and string are the dynamic bytes and string, encoded without padding.
+func EncodePacked(values ...interface{}) ([]byte, error) {
+	res := []byte{}
+	for i, v := range values {
+		b, err := encodePackedValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode value %d: %w", i, err)
+		}
+		res = append(res, b...)
+	}
+	return res, nil
+}
+
+func encodePackedValue(v interface{}) ([]byte, error) {
+	switch val := v.(type) {
+	case bool:
+		if val {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case uint8:
+		return []byte{val}, nil
+	case uint16:
+		return binary.BigEndian.AppendUint16(nil, val), nil
+	case uint32:
+		return binary.BigEndian.AppendUint32(nil, val), nil
+	case uint64:
+		return binary.BigEndian.AppendUint64(nil, val), nil
+	case int8:
+		return []byte{uint8(val)}, nil
+	case int16:
+		return binary.BigEndian.AppendUint16(nil, uint16(val)), nil
+	case int32:
+		return binary.BigEndian.AppendUint32(nil, uint32(val)), nil
+	case int64:
+		return binary.BigEndian.AppendUint64(nil, uint64(val)), nil
+	case *big.Int:
+		if val == nil {
+			return nil, fmt.Errorf("nil *big.Int")
+		}
+		if val.BitLen() > 256 {
+			return nil, fmt.Errorf("number %s does not fit into 256 bits", val)
+		}
+		return math.U256Bytes(new(big.Int).Set(val)), nil
+	case common.Address:
+		return val.Bytes(), nil
+	case []byte:
+		return val, nil
+	case string:
+		return []byte(val), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 && rv.Len() >= 1 && rv.Len() <= 32 {
+		res := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(res), rv)
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
@@ -0,0 +1,89 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodePacked(t *testing.T) {
+	t.Run("matches solidity example", func(t *testing.T) {
+		// abi.encodePacked(int16(-1), bytes1(0x42), uint16(0x03), string("Hello, world!"))
+		res, err := EncodePacked(int16(-1), [1]byte{0x42}, uint16(0x03), "Hello, world!")
+		assert.NoError(t, err)
+		assert.Equal(t, "ffff42000348656c6c6f2c20776f726c6421", common.Bytes2Hex(res))
+	})
+
+	t.Run("encodes types", func(t *testing.T) {
+		for _, test := range []struct {
+			value  interface{}
+			result string
+		}{
+			{value: true, result: "01"},
+			{value: false, result: "00"},
+			{value: uint8(1), result: "01"},
+			{value: uint32(1), result: "00000001"},
+			{value: uint64(1), result: "0000000000000001"},
+			{value: int8(-2), result: "fe"},
+			{value: int32(-1), result: "ffffffff"},
+			{value: int64(5), result: "0000000000000005"},
+			{value: big.NewInt(1), result: strings.Repeat("00", 31) + "01"},
+			{value: big.NewInt(-1), result: strings.Repeat("ff", 32)},
+			{value: common.HexToAddress("0x1be7B0F285d04701F27682F591a60417C47D095a"), result: "1be7b0f285d04701f27682f591a60417c47d095a"},
+			{value: common.HexToHash("0x01"), result: strings.Repeat("00", 31) + "01"},
+			{value: [4]byte{1, 2, 3, 4}, result: "01020304"},
+			{value: []byte{0xab, 0xcd}, result: "abcd"},
+			{value: "", result: ""},
+		} {
+			res, err := EncodePacked(test.value)
+			assert.NoError(t, err, "%T", test.value)
+			assert.Equal(t, test.result, common.Bytes2Hex(res), "%T", test.value)
+		}
+	})
+
+	t.Run("matches promise message", func(t *testing.T) {
+		promise := getPromise("consumer")
+		var channelID, hashlock [32]byte
+		copy(channelID[:], Pad(promise.ChannelID, 32))
+		copy(hashlock[:], Pad(promise.Hashlock, 32))
+
+		res, err := EncodePacked(big.NewInt(promise.ChainID), channelID, promise.Amount, promise.Fee, hashlock)
+		assert.NoError(t, err)
+		assert.Equal(t, promise.GetMessage(), res)
+	})
+
+	t.Run("rejects unsupported values", func(t *testing.T) {
+		for _, v := range []interface{}{
+			1,
+			uint(1),
+			(*big.Int)(nil),
+			new(big.Int).Lsh(big.NewInt(1), 256),
+			[33]byte{},
+			[0]byte{},
+			[]string{"a"},
+			nil,
+		} {
+			_, err := EncodePacked(v)
+			assert.Error(t, err, "%T", v)
+		}
+	})
+}
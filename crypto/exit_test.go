@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mysteriumnetwork/payments/v3/crypto/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +25,7 @@ func TestExit(t *testing.T) {
 		assert.Equal(t, validUntil, er.ValidUntil)
 	})
 
-	pk, err := crypto.GenerateKey()
+	pk, err := testutil.DeterministicKey(1)
 	require.NoError(t, err)
 	publicKeyECDSA, ok := pk.Public().(*ecdsa.PublicKey)
 	require.True(t, ok)
@@ -0,0 +1,61 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DeriveAddress derives a child key from the given BIP-32 master key following
+// the given derivation path, e.g. "m/44'/60'/0'/0/0", and returns its address.
+//
+// Path must be absolute, hardened path components require a private master key.
+func DeriveAddress(masterKey *hdkeychain.ExtendedKey, path string) (common.Address, error) {
+	if masterKey == nil {
+		return common.Address{}, fmt.Errorf("master key must be set")
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(path), "m/") {
+		return common.Address{}, fmt.Errorf("invalid derivation path %q: must start with \"m/\"", path)
+	}
+
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid derivation path %q: %w", path, err)
+	}
+
+	key := masterKey
+	for _, index := range derivationPath {
+		key, err = key.Derive(index)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("could not derive key for path %q: %w", path, err)
+		}
+	}
+
+	pub, err := key.ECPubKey()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("could not get public key: %w", err)
+	}
+
+	return crypto.PubkeyToAddress(*pub.ToECDSA()), nil
+}
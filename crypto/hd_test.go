@@ -0,0 +1,70 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"crypto/sha512"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func TestDeriveAddress(t *testing.T) {
+	// BIP-39 seed of the well known "abandon ... about" test mnemonic with no passphrase.
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	seed := pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"), 2048, 64, sha512.New)
+
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	assert.NoError(t, err)
+
+	t.Run("derives addresses", func(t *testing.T) {
+		addr, err := DeriveAddress(master, "m/44'/60'/0'/0/0")
+		assert.NoError(t, err)
+		assert.Equal(t, common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94"), addr)
+
+		addr2, err := DeriveAddress(master, "m/44'/60'/0'/0/1")
+		assert.NoError(t, err)
+		assert.NotEqual(t, addr, addr2)
+	})
+
+	t.Run("validates path", func(t *testing.T) {
+		for _, path := range []string{"", "44'/60'/0'/0/0", "m", "m/", "m/44'/x/0", "m/44'/60'/0'/0/4294967296"} {
+			_, err := DeriveAddress(master, path)
+			assert.Error(t, err, path)
+		}
+	})
+
+	t.Run("hardened path requires private key", func(t *testing.T) {
+		public, err := master.Neuter()
+		assert.NoError(t, err)
+
+		_, err = DeriveAddress(public, "m/44'/60'/0'/0/0")
+		assert.Error(t, err)
+
+		_, err = DeriveAddress(public, "m/0/0")
+		assert.NoError(t, err)
+	})
+
+	t.Run("requires master key", func(t *testing.T) {
+		_, err := DeriveAddress(nil, "m/44'/60'/0'/0/0")
+		assert.EqualError(t, err, "master key must be set")
+	})
+}
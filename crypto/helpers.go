@@ -17,7 +17,10 @@
 package crypto
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -27,7 +30,8 @@ func isHexCharacter(c byte) bool {
 	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
 }
 
-func isHex(str string) bool {
+// IsHex checks if the given string consists of an even number of hex characters.
+func IsHex(str string) bool {
 	if len(str)%2 != 0 {
 		return false
 	}
@@ -46,15 +50,113 @@ func toBytes32(address string) (string, error) {
 		return "", fmt.Errorf("given string is not a hex address")
 	}
 
-	res := strings.ToLower("000000000000000000000000" + ensureNoPrefix(address))
+	res := strings.ToLower("000000000000000000000000" + EnsureNoPrefix(address))
 
 	return res, nil
 }
 
-func ensureNoPrefix(address string) string {
+// ToBytes32Checksum left pads the given EIP-55 checksum address to 32 bytes
+// preserving its mixed case. Addresses with an invalid checksum are rejected.
+func ToBytes32Checksum(address string) (string, error) {
+	if err := ValidateChecksumAddress(address); err != nil {
+		return "", err
+	}
+
+	return "000000000000000000000000" + EnsureNoPrefix(address), nil
+}
+
+var (
+	// ErrAddressLength is returned for addresses which are not 20 bytes long.
+	ErrAddressLength = errors.New("address must be 40 hex characters long")
+	// ErrAddressNotHex is returned for addresses containing non hex characters.
+	ErrAddressNotHex = errors.New("address contains a non hex character")
+	// ErrAddressChecksum is returned for addresses with a missing or invalid EIP-55 checksum.
+	ErrAddressChecksum = errors.New("address has an invalid checksum")
+)
+
+// ValidateChecksumAddress checks that the given address, with or without the 0x prefix,
+// is a valid EIP-55 checksum address. Addresses in a single case carry no checksum
+// and are rejected as well.
+func ValidateChecksumAddress(addr string) error {
+	hexAddr := EnsureNoPrefix(addr)
+	if len(hexAddr) != 2*common.AddressLength {
+		return fmt.Errorf("%w: got %d in %q", ErrAddressLength, len(hexAddr), addr)
+	}
+
+	for i, c := range []byte(hexAddr) {
+		if !isHexCharacter(c) {
+			return fmt.Errorf("%w: %q at position %d in %q", ErrAddressNotHex, c, i, addr)
+		}
+	}
+
+	expected := common.HexToAddress(hexAddr).Hex()
+	if expected[2:] != hexAddr {
+		return fmt.Errorf("%w: expected %s, got %q", ErrAddressChecksum, expected, addr)
+	}
+
+	return nil
+}
+
+// EnsureNoPrefix removes the 0x prefix from the given string if it has one.
+func EnsureNoPrefix(address string) string {
 	return strings.TrimPrefix(address, "0x")
 }
 
+// EnsurePrefix adds the 0x prefix to the given string if it does not have one.
+func EnsurePrefix(str string) string {
+	if strings.HasPrefix(str, "0x") {
+		return str
+	}
+	return "0x" + str
+}
+
+// HexToBytes validates and decodes the given hex string, with or without the 0x prefix.
+func HexToBytes(str string) ([]byte, error) {
+	str = EnsureNoPrefix(str)
+	if !IsHex(str) {
+		return nil, fmt.Errorf("given string %q is not a valid hex", str)
+	}
+
+	return hex.DecodeString(str)
+}
+
+// BigIntToHex encodes the given number as a 0x prefixed hex string without leading zeros.
+// A nil number is encoded as zero.
+func BigIntToHex(n *big.Int) string {
+	if n == nil {
+		return "0x0"
+	}
+	if n.Sign() < 0 {
+		return "-0x" + new(big.Int).Neg(n).Text(16)
+	}
+	return "0x" + n.Text(16)
+}
+
+// HexToBigInt decodes the given hex string, with or without the 0x prefix, into a number.
+// Negative numbers and numbers which do not fit into uint256 are rejected.
+func HexToBigInt(s string) (*big.Int, error) {
+	if strings.HasPrefix(s, "-") {
+		return nil, fmt.Errorf("given string %q is a negative number", s)
+	}
+
+	str := EnsureNoPrefix(s)
+	if str == "" {
+		return nil, fmt.Errorf("given string %q is empty", s)
+	}
+	for _, c := range []byte(str) {
+		if !isHexCharacter(c) {
+			return nil, fmt.Errorf("given string %q is not a valid hex", s)
+		}
+	}
+
+	n, _ := new(big.Int).SetString(str, 16)
+	if n.BitLen() > 256 {
+		return nil, fmt.Errorf("given string %q does not fit into uint256", s)
+	}
+
+	return n, nil
+}
+
 // Pad pads the given byte array to given size by prefixing with zeros
 func Pad(b []byte, size int) []byte {
 	if len(b) >= size {
@@ -64,3 +166,36 @@ func Pad(b []byte, size int) []byte {
 	copy(tmp[size-len(b):], b)
 	return tmp
 }
+
+// PadRight pads the given byte array to given size by suffixing with zeros.
+// This matches the way Solidity encodes fixed size bytes and strings.
+func PadRight(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	tmp := make([]byte, size)
+	copy(tmp, b)
+	return tmp
+}
+
+// UnpadLeft strips the leading zeros of the given byte array.
+// An array consisting only of zeros is reduced to a single zero byte.
+func UnpadLeft(b []byte) []byte {
+	for i, c := range b {
+		if c != 0 {
+			return b[i:]
+		}
+	}
+	return []byte{0x00}
+}
+
+// UnpadRight strips the trailing zeros of the given byte array.
+// An array consisting only of zeros is reduced to a single zero byte.
+func UnpadRight(b []byte) []byte {
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] != 0 {
+			return b[:i+1]
+		}
+	}
+	return []byte{0x00}
+}
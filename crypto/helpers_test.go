@@ -17,9 +17,11 @@
 package crypto
 
 import (
+	"math/big"
 	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,9 +60,9 @@ func TestHelpers(t *testing.T) {
 				result: false,
 			},
 		} {
-			res := isHex(test.input)
+			res := IsHex(test.input)
 			if test.result != res {
-				t.Errorf("isHex(%s) = %t, want %t", test.input, res, test.result)
+				t.Errorf("IsHex(%s) = %t, want %t", test.input, res, test.result)
 			}
 		}
 	})
@@ -96,4 +98,178 @@ func TestHelpers(t *testing.T) {
 			}
 		}
 	})
+	t.Run("pad right", func(t *testing.T) {
+		expected := common.Hex2Bytes("68656c6c6f000000000000000000000000000000000000000000000000000000")
+		assert.Equal(t, expected, PadRight([]byte("hello"), 32))
+		assert.Len(t, PadRight([]byte("hello"), 32), 32)
+
+		// Should not touch inputs which are already long enough.
+		assert.Equal(t, []byte("hello"), PadRight([]byte("hello"), 5))
+		assert.Equal(t, []byte("hello"), PadRight([]byte("hello"), 2))
+		assert.Equal(t, make([]byte, 4), PadRight(nil, 4))
+	})
+	t.Run("unpad", func(t *testing.T) {
+		for _, test := range []struct {
+			input []byte
+			left  []byte
+			right []byte
+		}{
+			{
+				input: []byte{0x00, 0x00, 0x01, 0x02, 0x00},
+				left:  []byte{0x01, 0x02, 0x00},
+				right: []byte{0x00, 0x00, 0x01, 0x02},
+			},
+			{
+				input: []byte{0x01},
+				left:  []byte{0x01},
+				right: []byte{0x01},
+			},
+			{
+				input: make([]byte, 32),
+				left:  []byte{0x00},
+				right: []byte{0x00},
+			},
+			{
+				input: []byte{},
+				left:  []byte{0x00},
+				right: []byte{0x00},
+			},
+		} {
+			assert.Equal(t, test.left, UnpadLeft(test.input))
+			assert.Equal(t, test.right, UnpadRight(test.input))
+		}
+
+		assert.Equal(t, []byte("hello"), UnpadRight(PadRight([]byte("hello"), 32)))
+		assert.Equal(t, []byte{0x05}, UnpadLeft(Pad([]byte{0x05}, 32)))
+	})
+	t.Run("prefix", func(t *testing.T) {
+		assert.Equal(t, "abcd", EnsureNoPrefix("0xabcd"))
+		assert.Equal(t, "abcd", EnsureNoPrefix("abcd"))
+		assert.Equal(t, "0xabcd", EnsurePrefix("abcd"))
+		assert.Equal(t, "0xabcd", EnsurePrefix("0xabcd"))
+		assert.Equal(t, "0x", EnsurePrefix(""))
+	})
+
+	t.Run("hex to bytes", func(t *testing.T) {
+		for _, test := range []struct {
+			input  string
+			result []byte
+			err    bool
+		}{
+			{input: "0xabcd", result: []byte{0xab, 0xcd}},
+			{input: "ABCD", result: []byte{0xab, 0xcd}},
+			{input: "0x", result: []byte{}},
+			{input: "0xabc", err: true},
+			{input: "0xzz", err: true},
+		} {
+			res, err := HexToBytes(test.input)
+			if test.err {
+				assert.Error(t, err, test.input)
+			} else {
+				assert.NoError(t, err, test.input)
+				assert.Equal(t, test.result, res, test.input)
+			}
+		}
+	})
+	t.Run("big int to hex", func(t *testing.T) {
+		maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+		assert.Equal(t, "0x0", BigIntToHex(big.NewInt(0)))
+		assert.Equal(t, "0x0", BigIntToHex(nil))
+		assert.Equal(t, "0xff", BigIntToHex(big.NewInt(255)))
+		assert.Equal(t, "-0xff", BigIntToHex(big.NewInt(-255)))
+		assert.Equal(t, "0x"+strings.Repeat("f", 64), BigIntToHex(maxUint256))
+	})
+	t.Run("hex to big int", func(t *testing.T) {
+		maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+		for _, test := range []struct {
+			input  string
+			result *big.Int
+			err    bool
+		}{
+			{input: "0x0", result: big.NewInt(0)},
+			{input: "0", result: big.NewInt(0)},
+			{input: "0x00ff", result: big.NewInt(255)},
+			{input: "0xFF", result: big.NewInt(255)},
+			{input: "abc", result: big.NewInt(2748)},
+			{input: "0x" + strings.Repeat("f", 64), result: maxUint256},
+			{input: "0x1" + strings.Repeat("0", 64), err: true},
+			{input: "-0x1", err: true},
+			{input: "0x", err: true},
+			{input: "", err: true},
+			{input: "0xzz", err: true},
+			{input: "0x+1", err: true},
+			{input: "0x0x1", err: true},
+		} {
+			res, err := HexToBigInt(test.input)
+			if test.err {
+				assert.Error(t, err, test.input)
+			} else {
+				assert.NoError(t, err, test.input)
+				assert.Equal(t, 0, test.result.Cmp(res), test.input)
+			}
+		}
+
+		res, err := HexToBigInt(BigIntToHex(maxUint256))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, maxUint256.Cmp(res))
+	})
+	t.Run("validate checksum address", func(t *testing.T) {
+		for _, test := range []struct {
+			input string
+			err   error
+		}{
+			{input: "0x1be7B0F285d04701F27682F591a60417C47D095a"},
+			{input: "1be7B0F285d04701F27682F591a60417C47D095a"},
+			{input: "0x1be7b0f285d04701f27682f591a60417c47d095a", err: ErrAddressChecksum},
+			{input: "0x1BE7B0F285D04701F27682F591A60417C47D095A", err: ErrAddressChecksum},
+			{input: "0x1be7B0F285d04701F27682F591a60417C47D095A", err: ErrAddressChecksum},
+			{input: "0x1be7B0F285d04701F27682F591a60417C47D095", err: ErrAddressLength},
+			{input: "0x1be7B0F285d04701F27682F591a60417C47D095a00", err: ErrAddressLength},
+			{input: "", err: ErrAddressLength},
+			{input: "0x1be7B0F285d04701F27682F591a60417C47D095z", err: ErrAddressNotHex},
+		} {
+			err := ValidateChecksumAddress(test.input)
+			if test.err == nil {
+				assert.NoError(t, err, test.input)
+			} else {
+				assert.ErrorIs(t, err, test.err, test.input)
+			}
+		}
+	})
+	t.Run("to bytes checksum", func(t *testing.T) {
+		for _, test := range []struct {
+			input  string
+			result string
+		}{
+			{
+				input:  "0x1be7B0F285d04701F27682F591a60417C47D095a",
+				result: "0000000000000000000000001be7B0F285d04701F27682F591a60417C47D095a",
+			},
+			{
+				input:  "1be7B0F285d04701F27682F591a60417C47D095a",
+				result: "0000000000000000000000001be7B0F285d04701F27682F591a60417C47D095a",
+			},
+			{
+				// lowercase carries no checksum
+				input: "0x1be7b0f285d04701f27682f591a60417c47d095a",
+			},
+			{
+				// last character case flipped
+				input: "0x1be7B0F285d04701F27682F591a60417C47D095A",
+			},
+			{
+				input: "0x2345453",
+			},
+		} {
+			res, err := ToBytes32Checksum(test.input)
+			if test.result == "" {
+				assert.Error(t, err, test.input)
+			} else {
+				assert.NoError(t, err, test.input)
+				assert.Equal(t, test.result, res)
+			}
+		}
+	})
 }
@@ -23,7 +23,7 @@ func NewPayAndSettleBeneficiaryPayload(beneficiary common.Address, chainID int64
 	return &PayAndSettleBeneficiaryPayload{
 		Beneficiary:                    beneficiary,
 		ChainID:                        chainID,
-		ProviderChannelIDForWithdrawal: ensureNoPrefix(providerChannelIDForWithdrawal),
+		ProviderChannelIDForWithdrawal: EnsureNoPrefix(providerChannelIDForWithdrawal),
 		Amount:                         amount,
 		R:                              r,
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mysteriumnetwork/payments/v3/crypto/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,7 +36,7 @@ func TestPayAndSettle(t *testing.T) {
 		assert.Equal(t, r32, pas.R)
 	})
 
-	pk, err := crypto.GenerateKey()
+	pk, err := testutil.DeterministicKey(3)
 	require.NoError(t, err)
 	publicKeyECDSA, ok := pk.Public().(*ecdsa.PublicKey)
 	require.True(t, ok)
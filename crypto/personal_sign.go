@@ -0,0 +1,67 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PersonalSign signs the given data prefixed as described in EIP-191, matching
+// the personal_sign RPC method. The returned signature has V set to 27 or 28.
+func PersonalSign(data []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(accounts.TextHash(data), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ReformatSignatureVForBC(signature); err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// PersonalSignString signs the given text using `PersonalSign`.
+func PersonalSignString(text string, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	return PersonalSign([]byte(text), privateKey)
+}
+
+// RecoverPersonalSignSender recovers the address which signed the data using `PersonalSign`.
+// Signatures with V set either to 0/1 or 27/28 are accepted.
+func RecoverPersonalSignSender(data []byte, sig []byte) (common.Address, error) {
+	if len(sig) != 65 {
+		return common.Address{}, errors.New("the signature must be 65 bytes long")
+	}
+
+	signature := make([]byte, len(sig))
+	copy(signature, sig)
+	if err := ReformatSignatureVForRecovery(signature); err != nil {
+		return common.Address{}, err
+	}
+
+	pubKey, err := crypto.SigToPub(accounts.TextHash(data), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
@@ -0,0 +1,82 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalSign(t *testing.T) {
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("personal")))
+	assert.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	t.Run("signs and recovers data", func(t *testing.T) {
+		sig, err := PersonalSign([]byte{1, 2, 3}, key)
+		assert.NoError(t, err)
+		assert.Len(t, sig, 65)
+		assert.Contains(t, []byte{27, 28}, sig[64])
+
+		recovered, err := RecoverPersonalSignSender([]byte{1, 2, 3}, sig)
+		assert.NoError(t, err)
+		assert.Equal(t, signer, recovered)
+
+		// Signature must not be modified by the recovery.
+		assert.Contains(t, []byte{27, 28}, sig[64])
+	})
+
+	t.Run("signs text", func(t *testing.T) {
+		sig, err := PersonalSignString("hello", key)
+		assert.NoError(t, err)
+
+		sigData, err := PersonalSign([]byte("hello"), key)
+		assert.NoError(t, err)
+		assert.Equal(t, sigData, sig)
+
+		// Signing with the prefix must differ from signing the plain hash.
+		plain, err := crypto.Sign(crypto.Keccak256([]byte("hello")), key)
+		assert.NoError(t, err)
+		assert.NotEqual(t, plain[:64], sig[:64])
+	})
+
+	t.Run("recovers with V as 0 or 1", func(t *testing.T) {
+		sig, err := PersonalSign([]byte("data"), key)
+		assert.NoError(t, err)
+		sig[64] -= 27
+
+		recovered, err := RecoverPersonalSignSender([]byte("data"), sig)
+		assert.NoError(t, err)
+		assert.Equal(t, signer, recovered)
+	})
+
+	t.Run("recovers different sender for different data", func(t *testing.T) {
+		sig, err := PersonalSign([]byte("data"), key)
+		assert.NoError(t, err)
+
+		recovered, err := RecoverPersonalSignSender([]byte("other"), sig)
+		assert.NoError(t, err)
+		assert.NotEqual(t, signer, recovered)
+	})
+
+	t.Run("fails on invalid signature length", func(t *testing.T) {
+		_, err := RecoverPersonalSignSender([]byte("data"), []byte{1, 2, 3})
+		assert.Error(t, err)
+	})
+}
@@ -43,10 +43,10 @@ type Promise struct {
 
 // CreatePromise creates and signs new payment promise
 func CreatePromise(channelID string, chainID int64, amount *big.Int, fee *big.Int, hashlock string, ks hashSigner, signer common.Address) (*Promise, error) {
-	channelID = ensureNoPrefix(channelID)
-	hashlock = ensureNoPrefix(hashlock)
+	channelID = EnsureNoPrefix(channelID)
+	hashlock = EnsureNoPrefix(hashlock)
 
-	if !isHex(channelID) || !isHex(hashlock) {
+	if !IsHex(channelID) || !IsHex(hashlock) {
 		return nil, errors.New("channelID and hashlock have to be proper hex strings")
 	}
 
@@ -85,8 +85,8 @@ func CreatePromise(channelID string, chainID int64, amount *big.Int, fee *big.In
 // NewPromise will create new promise,
 // signature can be empty and be created later using `Sign()` method.
 func NewPromise(chainID int64, channelID string, amount, fee *big.Int, preimage string, signature string) (*Promise, error) {
-	channelID = ensureNoPrefix(channelID)
-	preimage = ensureNoPrefix(preimage)
+	channelID = EnsureNoPrefix(channelID)
+	preimage = EnsureNoPrefix(preimage)
 
 	chID, err := hex.DecodeString(channelID)
 	if err != nil {
@@ -120,8 +120,8 @@ func NewPromise(chainID int64, channelID string, amount, fee *big.Int, preimage
 
 // NewRawPromise creates a promise from given params. The promise has no R.
 func NewRawPromise(chainID int64, channelID string, amount, fee *big.Int, hashlock string, signature string) (*Promise, error) {
-	channelID = ensureNoPrefix(channelID)
-	hashlock = ensureNoPrefix(hashlock)
+	channelID = EnsureNoPrefix(channelID)
+	hashlock = EnsureNoPrefix(hashlock)
 
 	chID, err := hex.DecodeString(channelID)
 	if err != nil {
@@ -217,6 +217,13 @@ func (p Promise) IsPromiseValid(expectedSigner common.Address) bool {
 	return recoveredSigner == expectedSigner
 }
 
+// VerifyPromise checks if the given promise was signed by signerAddress.
+// Unlike `IsPromiseValid` it reports malformed signatures as errors
+// and also accepts compact 64 byte signatures.
+func VerifyPromise(promise Promise, signerAddress common.Address) (bool, error) {
+	return VerifySignature(signerAddress, promise.GetMessage(), promise.Signature)
+}
+
 // RecoverSigner recovers signer address out of promise signature
 func (p Promise) RecoverSigner() (common.Address, error) {
 	sig := make([]byte, 65)
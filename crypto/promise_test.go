@@ -101,6 +101,28 @@ func TestProviderPromiseValidation(t *testing.T) {
 	assert.False(t, promise.IsPromiseValid(wrongSigner))
 }
 
+func TestVerifyPromise(t *testing.T) {
+	promise := getPromise("provider")
+
+	ok, err := VerifyPromise(promise, common.HexToAddress("0x354bd098b4ef8c9e70b7f21be2d455df559705d7"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = VerifyPromise(promise, common.HexToAddress("0xf53acdd584ccb85ee4ec1590007ad3c16fdff057"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	tampered := promise
+	tampered.Amount = new(big.Int).Add(promise.Amount, big.NewInt(1))
+	ok, err = VerifyPromise(tampered, common.HexToAddress("0x354bd098b4ef8c9e70b7f21be2d455df559705d7"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	promise.Signature = promise.Signature[:10]
+	_, err = VerifyPromise(promise, common.HexToAddress("0x354bd098b4ef8c9e70b7f21be2d455df559705d7"))
+	assert.Error(t, err)
+}
+
 func TestRecoverSigner(t *testing.T) {
 	promise := getPromise("consumer")
 	expectedSigner := common.HexToAddress("0xf53acdd584ccb85ee4ec1590007ad3c16fdff057")
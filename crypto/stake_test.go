@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mysteriumnetwork/payments/v3/crypto/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,7 +37,7 @@ func TestStake(t *testing.T) {
 		ChainID:       chain,
 	}
 
-	pk, err := crypto.GenerateKey()
+	pk, err := testutil.DeterministicKey(2)
 	require.NoError(t, err)
 	publicKeyECDSA, ok := pk.Public().(*ecdsa.PublicKey)
 	require.True(t, ok)
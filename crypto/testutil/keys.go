@@ -0,0 +1,53 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package testutil provides helpers for reproducible crypto tests.
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxKeyAttempts limits how many candidates are drawn for a single seed.
+// A candidate is invalid with a probability of about 2^-128, so this is never reached in practice.
+const maxKeyAttempts = 16
+
+// DeterministicKey derives a private key from the given seed.
+// The same seed always results in the same key, different seeds in different keys.
+//
+// Candidates are drawn from an HMAC-SHA256 based DRBG keyed with the seed
+// until one is a valid secp256k1 private key. Never use these keys outside of tests.
+func DeterministicKey(seed int64) (*ecdsa.PrivateKey, error) {
+	key := binary.BigEndian.AppendUint64(nil, uint64(seed))
+
+	for counter := uint32(0); counter < maxKeyAttempts; counter++ {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(binary.BigEndian.AppendUint32(nil, counter))
+
+		pk, err := crypto.ToECDSA(mac.Sum(nil))
+		if err == nil {
+			return pk, nil
+		}
+	}
+
+	return nil, errors.New("could not derive a valid private key")
+}
@@ -0,0 +1,43 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeterministicKey(t *testing.T) {
+	first, err := DeterministicKey(1)
+	assert.NoError(t, err)
+
+	again, err := DeterministicKey(1)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.FromECDSA(first), crypto.FromECDSA(again))
+
+	seen := map[string]bool{}
+	for _, seed := range []int64{0, 1, 2, -1, 1 << 40} {
+		pk, err := DeterministicKey(seed)
+		assert.NoError(t, err)
+
+		addr := crypto.PubkeyToAddress(pk.PublicKey).Hex()
+		assert.False(t, seen[addr], "seed %d derived a key of another seed", seed)
+		seen[addr] = true
+	}
+}
@@ -25,7 +25,6 @@ import (
 
 	"github.com/mysteriumnetwork/payments/v3/client"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -50,6 +49,10 @@ type Delivery struct {
 
 	CreatedUTC time.Time
 	UpdateUTC  time.Time
+
+	// allowUnprotected is set by the depot before handing the delivery
+	// to a courier and is used to validate the signed transaction.
+	allowUnprotected bool
 }
 
 // DeliverableType is issued for the Courier to determine how to package a transaction.
@@ -76,10 +79,22 @@ var ErrNoTrasactionExists = errors.New("transaction doesn't exist")
 type SignFunc func(common.Address, *types.Transaction) (*types.Transaction, error)
 
 // ToWriteRequest will convert a Delivery to a typical write request used by `client` package.
+// Transactions signed for a different chain than the delivery are refused
+// by the signer, so they are never sent out.
 func (t *Delivery) ToWriteRequest(signer SignFunc, gasLimit uint64) client.WriteRequest {
+	td := *t
 	wr := client.WriteRequest{
 		Identity: t.Sender,
-		Signer:   bind.SignerFn(signer),
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			signed, err := signer(addr, tx)
+			if err != nil {
+				return nil, err
+			}
+			if err := validateChainID(td, signed, td.allowUnprotected); err != nil {
+				return nil, err
+			}
+			return signed, nil
+		},
 		GasLimit: gasLimit,
 		Nonce:    new(big.Int).SetUint64(t.Nonce),
 	}
@@ -128,6 +143,10 @@ type DeliveryRequest struct {
 
 	// Data must always be a marshable struct or nil
 	Data interface{}
+
+	// IdempotencyKey is optional. If set, enqueueing a request with
+	// the same key again will not create a new delivery.
+	IdempotencyKey string
 }
 
 func (t *DeliveryRequest) toDelivery(nonce uint64) (Delivery, error) {
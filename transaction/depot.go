@@ -17,10 +17,12 @@
 package transaction
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -44,8 +46,15 @@ type Depot struct {
 	config        DepotConfig
 	cleanupConfig DepotCleanupConfig
 
-	logFn   func(error)
+	logger  TransactionLogger
 	metrics DepotMetricsExporter
+	events  chan DeliveryEvent
+
+	idempotency     DepotIdempotencyStore
+	idempotencyLock sync.Mutex
+
+	shuttingDown atomic.Bool
+	waiting      atomic.Int64
 
 	once sync.Once
 	stop chan struct{}
@@ -54,7 +63,22 @@ type Depot struct {
 type DepotConfig struct {
 	Workers         []DepotWorker
 	MaxNonDelivered uint
-	ForceResend     time.Duration
+	// MaxNonDeliveredTotal caps non delivered transactions across all workers.
+	// Zero means no global limit.
+	MaxNonDeliveredTotal uint
+	ForceResend          time.Duration
+	// MaxWaiting caps the number of requests waiting in `EnqueueDeliveryWait`
+	// for a free slot in the queue. Zero means no limit.
+	MaxWaiting uint
+	// AllowUnprotected allows couriers to deliver legacy transactions without
+	// EIP-155 replay protection. Such transactions are valid on every chain and
+	// can be replayed by anyone on any other chain where the sender has funds,
+	// so only enable it for chains or contracts that do not support EIP-155.
+	// Protected transactions are still checked against the delivery chain ID.
+	AllowUnprotected bool
+	// EventsBuffer is the size of the buffer of the `Events` channel.
+	// Events which do not fit into the buffer are dropped.
+	EventsBuffer uint
 }
 
 // DepotWorker is a worker that will spawn upon starting `Run`.
@@ -123,6 +147,32 @@ type DepotNonceTracker interface {
 
 var ErrImpossibleToDeliver = errors.New("impossible to deliver")
 
+// ErrChainIDMismatch is returned when a courier signed a transaction
+// for a different chain than the one requested. Such deliveries keep
+// their nonce and stay queued, so they are retried with the same nonce.
+var ErrChainIDMismatch = errors.New("transaction chain ID does not match delivery chain ID")
+
+// ErrQueueFull is returned when the number of non delivered
+// transactions of a sender reached `MaxNonDelivered`.
+var ErrQueueFull = errors.New("delivery queue is full")
+
+// ErrWaitingRoomFull is returned by `EnqueueDeliveryWait` when
+// `MaxWaiting` requests are already waiting for a free slot.
+var ErrWaitingRoomFull = errors.New("delivery waiting room is full")
+
+// ErrGlobalQueueFull is returned when the total number of non delivered
+// transactions across all workers reached `MaxNonDeliveredTotal`.
+var ErrGlobalQueueFull = errors.New("global delivery queue is full")
+
+// ErrShutdown is returned for deliveries enqueued after `Shutdown` was called.
+var ErrShutdown = errors.New("depot is shutting down")
+
+// enqueueWaitInterval is how often `EnqueueDeliveryWait` retries to enqueue a delivery.
+const enqueueWaitInterval = time.Millisecond * 100
+
+// shutdownPollInterval is how often `Shutdown` checks if all deliveries were delivered.
+const shutdownPollInterval = time.Millisecond * 100
+
 // NewDepot will returns a new depot.
 func NewDepot(handler DeliveryCourier, storage DepotStorage, nonce DepotNonceTracker, gasStation *GasTracker, cfg DepotConfig) *Depot {
 	return &Depot{
@@ -131,8 +181,11 @@ func NewDepot(handler DeliveryCourier, storage DepotStorage, nonce DepotNonceTra
 		nonceTracker: nonce,
 		gasStation:   gasStation,
 
-		logFn:   func(error) {},
+		logger:  FuncLogger(nil),
 		metrics: &depotMetricsExporterNoop{},
+		events:  make(chan DeliveryEvent, cfg.EventsBuffer),
+
+		idempotency: NewInMemoryIdempotencyStore(),
 
 		config: cfg,
 		stop:   make(chan struct{}),
@@ -148,7 +201,77 @@ func (d *Depot) Run() {
 
 // EnqueueDelivery will submit a new transaction to the delivery queue.
 // It will return a unique tracking number which can be used to see the status of a transaction.
+//
+// If the request carries an idempotency key which was already used,
+// the tracking number of the earlier delivery is returned instead.
 func (d *Depot) EnqueueDelivery(req DeliveryRequest, force bool) (string, error) {
+	if req.IdempotencyKey == "" {
+		return d.enqueueDelivery(req, force)
+	}
+
+	d.idempotencyLock.Lock()
+	defer d.idempotencyLock.Unlock()
+
+	unqID, err := d.idempotency.GetUniqueID(req.IdempotencyKey)
+	if err != nil {
+		return "", fmt.Errorf("could not check idempotency key: %w", err)
+	}
+	if unqID != "" {
+		return unqID, nil
+	}
+
+	unqID, err = d.enqueueDelivery(req, force)
+	if err != nil {
+		return "", err
+	}
+
+	if err := d.idempotency.SetUniqueID(req.IdempotencyKey, unqID); err != nil {
+		d.log(LogLevelError, "failed to save idempotency key", err)
+	}
+
+	return unqID, nil
+}
+
+// EnqueueDeliveryWait works like `EnqueueDelivery`, but if the queue is full
+// it waits for a free slot instead of failing until the context is done.
+func (d *Depot) EnqueueDeliveryWait(ctx context.Context, req DeliveryRequest) (string, error) {
+	unqID, err := d.EnqueueDelivery(req, false)
+	if !isQueueFull(err) {
+		return unqID, err
+	}
+
+	waiting := d.waiting.Add(1)
+	defer d.waiting.Add(-1)
+	if d.config.MaxWaiting > 0 && waiting > int64(d.config.MaxWaiting) {
+		return "", fmt.Errorf("%w: max count of %d reached", ErrWaitingRoomFull, d.config.MaxWaiting)
+	}
+
+	ticker := time.NewTicker(enqueueWaitInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("gave up waiting for a free slot: %w", ctx.Err())
+		case <-ticker.C:
+		}
+
+		unqID, err := d.EnqueueDelivery(req, false)
+		if !isQueueFull(err) {
+			return unqID, err
+		}
+	}
+}
+
+func isQueueFull(err error) bool {
+	return errors.Is(err, ErrQueueFull) || errors.Is(err, ErrGlobalQueueFull)
+}
+
+func (d *Depot) enqueueDelivery(req DeliveryRequest, force bool) (string, error) {
+	if d.shuttingDown.Load() {
+		return "", ErrShutdown
+	}
+
 	if !d.workerExists(req) {
 		return "", fmt.Errorf("failed to enqueue for sender %q on chain %q: no worker found", req.Sender.Hex(), req.ChainID)
 	}
@@ -163,7 +286,17 @@ func (d *Depot) EnqueueDelivery(req DeliveryRequest, force bool) (string, error)
 	}
 
 	if !force && d.config.MaxNonDelivered <= count {
-		return "", fmt.Errorf("cannot queue a new entry, max count of %d reached", d.config.MaxNonDelivered)
+		return "", fmt.Errorf("cannot queue a new entry, max count of %d reached: %w", d.config.MaxNonDelivered, ErrQueueFull)
+	}
+
+	if !force && d.config.MaxNonDeliveredTotal > 0 {
+		total, err := d.totalPendingCount()
+		if err != nil {
+			return "", err
+		}
+		if d.config.MaxNonDeliveredTotal <= total {
+			return "", fmt.Errorf("%w: max count of %d reached", ErrGlobalQueueFull, d.config.MaxNonDeliveredTotal)
+		}
 	}
 
 	unqID := ""
@@ -190,6 +323,17 @@ func (d *Depot) EnqueueDelivery(req DeliveryRequest, force bool) (string, error)
 	return unqID, nil
 }
 
+// PendingCount returns the number of non delivered transactions queued for the given sender.
+// New deliveries are rejected once it reaches the configured `MaxNonDelivered`.
+func (d *Depot) PendingCount(chainID int64, sender common.Address) (uint, error) {
+	count, err := d.storage.GetNonDeliveredCount(chainID, sender)
+	if err != nil {
+		return 0, fmt.Errorf("could not get non delivered count: %w", err)
+	}
+
+	return count, nil
+}
+
 // Stop will stop the Deposit goroutines.
 func (d *Depot) Stop() {
 	d.once.Do(func() {
@@ -197,13 +341,78 @@ func (d *Depot) Stop() {
 	})
 }
 
+// Shutdown stops accepting new deliveries and waits until all queued
+// deliveries of every worker are delivered or the given context is done.
+// Depot goroutines are stopped in both cases.
+func (d *Depot) Shutdown(ctx context.Context) error {
+	d.shuttingDown.Store(true)
+	defer d.Stop()
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	for {
+		drained, err := d.isDrained()
+		if err != nil {
+			return err
+		}
+		if drained {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Depot) isDrained() (bool, error) {
+	total, err := d.totalPendingCount()
+	if err != nil {
+		return false, err
+	}
+
+	return total == 0, nil
+}
+
+func (d *Depot) totalPendingCount() (uint, error) {
+	var total uint
+	for _, w := range d.config.Workers {
+		count, err := d.PendingCount(w.ChainID, w.Address)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
 // AttachLogger allows the caller to attach an optional logger.
 // Logger logs non critical errors that happen during transaction
 // handling and will be eventually handled by the Depot.
 //
 // This method is not thread safe and should be called before `Run`.
 func (d *Depot) AttachLogger(fn func(err error)) {
-	d.logFn = fn
+	d.logger = FuncLogger(fn)
+}
+
+// AttachTransactionLogger allows the caller to attach an optional logger
+// which also receives the level and context of each logged entry.
+//
+// This method is not thread safe and should be called before `Run`.
+func (d *Depot) AttachTransactionLogger(l TransactionLogger) {
+	d.logger = l
+}
+
+// AttachIdempotencyStore allows the caller to replace the default in memory
+// store used to track idempotency keys, e.g. with a persistent one.
+//
+// This method is not thread safe and should be called before `Run`.
+func (d *Depot) AttachIdempotencyStore(s DepotIdempotencyStore) {
+	d.idempotency = s
 }
 
 // AttachMetricsReporter allows the caller to attach a custom metrics reporter
@@ -230,7 +439,7 @@ func (d *Depot) watchDeliveries(s DepotWorker) {
 		case <-time.After(s.ProcessInterval):
 			tds, err := d.storage.GetOrderedDeliveryRequests(s.ProcessCount, s.ChainID, s.Address)
 			if err != nil {
-				d.log(err)
+				d.log(LogLevelError, "failed to get ordered delivery requests", err)
 				break
 			}
 
@@ -259,7 +468,7 @@ func (d *Depot) RunCleaner() {
 
 func (d *Depot) startCleanupThread() {
 	if d.cleanupConfig.CleanupInterval <= 0 || d.cleanupConfig.CleanupDaysLimit <= 0 || d.cleanupConfig.CleanupLimit <= 0 {
-		d.log(fmt.Errorf("invalid cleanup config"))
+		d.log(LogLevelError, "cleaner not started", fmt.Errorf("invalid cleanup config"))
 		return
 	}
 	for {
@@ -272,13 +481,13 @@ func (d *Depot) startCleanupThread() {
 				olderThan := time.Now().UTC().Add(-time.Duration(hours) * time.Hour)
 				tds, err := d.storageCleaner.GetDeliveredDeliveryRequests(worker.ChainID, worker.Address, olderThan, d.cleanupConfig.CleanupLimit)
 				if err != nil {
-					d.log(err)
+					d.log(LogLevelError, "failed to get delivered delivery requests", err)
 					break
 				}
 
 				err = d.storageCleaner.DeleteDelivery(tds...)
 				if err != nil {
-					d.log(err)
+					d.log(LogLevelError, "failed to delete deliveries", err)
 				}
 			}
 
@@ -290,11 +499,11 @@ func (d *Depot) handleDeliveryRequest(td Delivery) {
 	switch td.State {
 	case DeliveryStateWaiting:
 		if err := d.handleWaiting(td); err != nil {
-			d.log(err)
+			d.log(LogLevelError, "failed to handle waiting delivery", err)
 		}
 	case DeliveryStatePacking, DeliveryStateSent:
 		if err := d.handleTracking(td); err != nil {
-			d.log(err)
+			d.log(LogLevelError, "failed to track delivery", err)
 		}
 	}
 }
@@ -318,6 +527,7 @@ func (d *Depot) handleTracking(td Delivery) error {
 		}
 
 		d.metrics.DeliveryReceived(td)
+		d.emit(DeliveryEventDelivered, td)
 		return nil
 	}
 
@@ -331,35 +541,44 @@ func (d *Depot) handleTracking(td Delivery) error {
 	// If state is packing, reset the gas price and resend the transaction
 	// as we do not know if it was ever sent out.
 	if td.State == DeliveryStatePacking {
-		d.log(fmt.Errorf("got transaction in packing state %q, will retry", td.UniqueID))
+		d.log(LogLevelWarn, "retrying packing delivery", fmt.Errorf("got transaction in packing state %q, will retry", td.UniqueID))
 		updated, err := d.calculateNewGasPrice(td)
 		if err != nil {
 			return err
 		}
-		_, err = d.sendOutTransaction(updated)
+		sent, err := d.sendOutTransaction(updated)
 		if err != nil {
 			return fmt.Errorf("failed to send packing tx: %w", err)
 		}
+		d.emit(DeliveryEventResent, sent)
 		return nil
 	}
 
 	updated, err := d.calculateNewGasPrice(td)
 	if err != nil {
-		if errors.Is(err, errMaxPriceReached) && d.shouldForceResend(td) {
-			_, err = d.sendOutTransaction(updated)
+		if !errors.Is(err, ErrGasPriceCapped) {
+			return err
+		}
+
+		if d.shouldForceResend(td) {
+			sent, err := d.sendOutTransaction(updated)
 			if err != nil {
 				return fmt.Errorf("failed to force resend: %w", err)
 			}
+			d.emit(DeliveryEventResent, sent)
 			return nil
 		}
-		return err
+
+		d.log(LogLevelWarn, "gas price capped, not bumping delivery", fmt.Errorf("delivery %q: %w", td.UniqueID, err))
+		return nil
 	}
 
 	if updated.GasTip.Cmp(td.GasTip) > 0 {
-		_, err = d.sendOutTransaction(updated)
+		sent, err := d.sendOutTransaction(updated)
 		if err != nil {
 			return fmt.Errorf("failed to resend with new gas tip: %w", err)
 		}
+		d.emit(DeliveryEventResent, sent)
 		return nil
 	}
 	return nil
@@ -383,11 +602,18 @@ func (d *Depot) handleWaiting(td Delivery) error {
 	}
 
 	d.metrics.DeliverySent(td)
+	d.emit(DeliveryEventSent, td)
 	return nil
 }
 
 func (d *Depot) sendOutTransaction(td Delivery) (Delivery, error) {
+	td.allowUnprotected = d.config.AllowUnprotected
 	tx, err := d.handler.DeliverTransaction(td)
+	if err == nil {
+		// Couriers which do not sign through `ToWriteRequest` are only
+		// validated after the transaction was delivered.
+		err = validateChainID(td, tx, d.config.AllowUnprotected)
+	}
 	if err != nil {
 		return td, fmt.Errorf("attempted to delivery a transaction %q for account %q but failed: %w", td.UniqueID, td.Sender.Hex(), err)
 	}
@@ -400,6 +626,18 @@ func (d *Depot) sendOutTransaction(td Delivery) (Delivery, error) {
 	return td, nil
 }
 
+func validateChainID(td Delivery, tx *types.Transaction, allowUnprotected bool) error {
+	if allowUnprotected && !tx.Protected() {
+		return nil
+	}
+
+	if tx.ChainId().Cmp(big.NewInt(td.ChainID)) != 0 {
+		return fmt.Errorf("%w: delivery %q expected %d, got %s", ErrChainIDMismatch, td.UniqueID, td.ChainID, tx.ChainId())
+	}
+
+	return nil
+}
+
 func (d *Depot) calculateNewGasPrice(td Delivery) (Delivery, error) {
 	var newPrice *fees
 	switch td.State {
@@ -432,11 +670,19 @@ func (d *Depot) calculateNewGasPrice(td Delivery) (Delivery, error) {
 
 		gasPrice, err := d.gasStation.RecalculateDeliveryGas(td.ChainID, td.GasTip, td.Type)
 		if err != nil {
-			if errors.Is(errMaxPriceReached, err) {
+			if errors.Is(ErrGasPriceCapped, err) {
 				return td, err
 			}
 			return Delivery{}, err
 		}
+
+		// Fee cap is base + tip, both of them must be bumped
+		// for the node to accept the replacement transaction.
+		if td.BaseFee != nil && td.BaseFee.Cmp(big.NewInt(0)) > 0 {
+			if minBase := minReplacementFee(td.BaseFee); gasPrice.Base == nil || gasPrice.Base.Cmp(minBase) < 0 {
+				gasPrice.Base = minBase
+			}
+		}
 		newPrice = gasPrice
 
 	default:
@@ -511,8 +757,8 @@ func (d *Depot) shouldForceResend(td Delivery) bool {
 	return resendAfter.Before(now)
 }
 
-func (d *Depot) log(err error) {
-	if d.logFn != nil {
-		d.logFn(err)
+func (d *Depot) log(level, msg string, err error) {
+	if d.logger != nil {
+		d.logger.Log(level, msg, err)
 	}
 }
@@ -1,6 +1,8 @@
 package transaction
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/mysteriumnetwork/payments/v3/transaction/gas"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
@@ -439,6 +442,579 @@ func TestDepot(t *testing.T) {
 
 }
 
+func TestDepotForceEnqueueChecksCourier(t *testing.T) {
+	senderAddr := common.Address{}
+	mockStorage := mockStorage{
+		deliveries: []Delivery{},
+	}
+	mockNonceTracker := mockNonceTracker{
+		nonces: make(map[string]uint64),
+	}
+	mockCourier := mockCourier{
+		lastDeliveredNonce: -1,
+		undeliverable:      "unsupported",
+	}
+	depot := NewDepot(&mockCourier, &mockStorage, &mockNonceTracker, nil, DepotConfig{
+		MaxNonDelivered: 0,
+		Workers: []DepotWorker{
+			{
+				Address: senderAddr,
+				ChainID: chainId,
+			},
+		},
+	})
+
+	for _, force := range []bool{false, true} {
+		_, err := depot.EnqueueDelivery(DeliveryRequest{
+			ChainID: chainId,
+			Sender:  senderAddr,
+			Type:    "unsupported",
+		}, force)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not possible to delivery type")
+	}
+
+	// Force only skips the queue capacity check.
+	_, err := depot.EnqueueDelivery(DeliveryRequest{
+		ChainID: chainId,
+		Sender:  senderAddr,
+		Type:    "test",
+	}, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "max count of 0 reached")
+
+	_, err = depot.EnqueueDelivery(DeliveryRequest{
+		ChainID: chainId,
+		Sender:  senderAddr,
+		Type:    "test",
+	}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mockStorage.length())
+
+	count, err := depot.PendingCount(chainId, senderAddr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), count)
+
+	count, err = depot.PendingCount(chainId, common.HexToAddress("0x1"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), count)
+}
+
+func TestDepotIdempotencyKey(t *testing.T) {
+	senderAddr := common.Address{}
+	mockStorage := mockStorage{
+		deliveries: []Delivery{},
+	}
+	mockNonceTracker := mockNonceTracker{
+		nonces: make(map[string]uint64),
+	}
+	mockCourier := mockCourier{
+		lastDeliveredNonce: -1,
+	}
+	depot := NewDepot(&mockCourier, &mockStorage, &mockNonceTracker, nil, DepotConfig{
+		MaxNonDelivered: 10,
+		Workers: []DepotWorker{
+			{
+				Address: senderAddr,
+				ChainID: chainId,
+			},
+		},
+	})
+
+	req := DeliveryRequest{
+		ChainID:        chainId,
+		Sender:         senderAddr,
+		Type:           "test",
+		IdempotencyKey: "settle-1",
+	}
+
+	var wg sync.WaitGroup
+	ids := make([]string, 10)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id, err := depot.EnqueueDelivery(req, false)
+			assert.NoError(t, err)
+			ids[i] = id
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, mockStorage.length())
+	for _, id := range ids {
+		assert.Equal(t, ids[0], id)
+	}
+
+	req.IdempotencyKey = "settle-2"
+	id, err := depot.EnqueueDelivery(req, false)
+	assert.NoError(t, err)
+	assert.NotEqual(t, ids[0], id)
+
+	req.IdempotencyKey = ""
+	_, err = depot.EnqueueDelivery(req, false)
+	assert.NoError(t, err)
+	_, err = depot.EnqueueDelivery(req, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, mockStorage.length())
+}
+
+func TestDepotChainIDMismatch(t *testing.T) {
+	storage := mockStorage{
+		deliveries: []Delivery{},
+	}
+	courier := mockCourier{
+		lastDeliveredNonce: -1,
+		signChainID:        chainId + 1,
+	}
+	depot := NewDepot(&courier, &storage, &mockNonceTracker{nonces: make(map[string]uint64)}, nil, DepotConfig{})
+
+	td := Delivery{
+		UniqueID: "1",
+		ChainID:  chainId,
+		GasPrice: big.NewInt(1),
+		State:    DeliveryStatePacking,
+	}
+	_, err := depot.sendOutTransaction(td)
+	assert.ErrorIs(t, err, ErrChainIDMismatch)
+	assert.Empty(t, courier.getSent(), "mismatched transaction should never be sent")
+	assert.Equal(t, 0, storage.length(), "mismatched transaction should not be tracked as sent")
+
+	courier.signChainID = 0
+	td, err = depot.sendOutTransaction(td)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveryState(DeliveryStateSent), td.State)
+
+	t.Run("keeps the nonce and drains once signed for the right chain", func(t *testing.T) {
+		nonceTracker := &mockNonceTracker{
+			nonces:      make(map[string]uint64),
+			confirmNone: true,
+		}
+		depot, storage := newTestDepot(t, nonceTracker, DepotConfig{MaxNonDelivered: 5})
+		courier := depot.handler.(*mockCourier)
+		courier.setSignChainID(chainId + 1)
+		depot.Run()
+
+		req := DeliveryRequest{ChainID: chainId, Sender: common.Address{}, Type: "test"}
+		for i := 0; i < 2; i++ {
+			_, err := depot.EnqueueDelivery(req, false)
+			assert.NoError(t, err)
+		}
+
+		// Deliveries are retried with the nonces they were given.
+		assert.Eventually(t, func() bool {
+			return courier.getCalls() > 2
+		}, time.Second, time.Millisecond*10)
+		assert.Empty(t, courier.getSent())
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, uint64(i), storage.get(i).Nonce)
+			assert.Equal(t, DeliveryState(DeliveryStatePacking), storage.get(i).State)
+		}
+
+		// The reserved nonce is used once the courier signs for the right chain,
+		// so there is no gap and later deliveries are not stuck behind it.
+		courier.setSignChainID(0)
+		assert.Eventually(t, func() bool {
+			return storage.get(0).State == DeliveryStateSent
+		}, time.Second, time.Millisecond*10)
+		sent := courier.getSent()
+		assert.Len(t, sent, 1)
+		assert.Equal(t, uint64(0), sent[0].Nonce())
+
+		nonceTracker.setConfirmAll(true)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+		defer cancel()
+		assert.NoError(t, depot.Shutdown(ctx))
+		for i := 0; i < storage.length(); i++ {
+			assert.Equal(t, DeliveryState(DeliveryStateDelivered), storage.get(i).State)
+		}
+	})
+}
+
+func TestDepotChainIDCheckAfterDelivery(t *testing.T) {
+	for _, test := range []struct {
+		name             string
+		signChainID      int64
+		unprotected      bool
+		allowUnprotected bool
+		accepted         bool
+	}{
+		{name: "protected for the delivery chain", accepted: true},
+		{name: "protected for another chain", signChainID: chainId + 1},
+		{name: "unprotected", unprotected: true},
+		{name: "unprotected allowed", unprotected: true, allowUnprotected: true, accepted: true},
+		{name: "protected for another chain with unprotected allowed", signChainID: chainId + 1, allowUnprotected: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			nonceTracker := &mockNonceTracker{
+				nonces:      make(map[string]uint64),
+				confirmNone: true,
+			}
+			depot, storage := newTestDepot(t, nonceTracker, DepotConfig{
+				MaxNonDelivered:  5,
+				AllowUnprotected: test.allowUnprotected,
+			})
+			courier := depot.handler.(*mockCourier)
+			courier.skipWriteRequest = true
+			courier.signChainID = test.signChainID
+			courier.unprotected = test.unprotected
+			depot.Run()
+			defer depot.Stop()
+
+			_, err := depot.EnqueueDelivery(DeliveryRequest{ChainID: chainId, Sender: common.Address{}, Type: "test"}, false)
+			assert.NoError(t, err)
+
+			if test.accepted {
+				assert.Eventually(t, func() bool {
+					return storage.get(0).State == DeliveryStateSent
+				}, time.Second, time.Millisecond*10)
+				assert.NotEmpty(t, storage.get(0).SentTransaction)
+				return
+			}
+
+			// Rejected delivery is not marked as sent and is retried with its nonce.
+			assert.Eventually(t, func() bool {
+				return courier.getCalls() > 1
+			}, time.Second, time.Millisecond*10)
+			d := storage.get(0)
+			assert.Equal(t, DeliveryState(DeliveryStatePacking), d.State)
+			assert.Empty(t, d.SentTransaction)
+			assert.Equal(t, uint64(0), d.Nonce)
+			for _, tx := range courier.getSent() {
+				assert.Equal(t, uint64(0), tx.Nonce())
+			}
+		})
+	}
+}
+
+func TestDepotResendBumpsFees(t *testing.T) {
+	// Gas station prices did not change since the delivery was sent,
+	// so only the replacement rules can raise them.
+	price := big.NewInt(100)
+	gasTracker := NewGasTracker(&mockGasStation{
+		defaultPrice:   price,
+		defaultBaseFee: price,
+	}, map[int64]GasIncreaseOpts{
+		chainId: {
+			Multiplier:       1.01,
+			PriceLimit:       big.NewInt(1000),
+			IncreaseInterval: time.Millisecond,
+		},
+	}, GasTrackerSpeedMedium)
+	depot := NewDepot(&mockCourier{lastDeliveredNonce: -1}, &mockStorage{deliveries: []Delivery{}}, &mockNonceTracker{nonces: make(map[string]uint64)}, gasTracker, DepotConfig{})
+
+	td := Delivery{
+		UniqueID:  "1",
+		ChainID:   chainId,
+		State:     DeliveryStateSent,
+		GasTip:    price,
+		BaseFee:   price,
+		UpdateUTC: time.Now().UTC().Add(-time.Minute),
+	}
+	updated, err := depot.calculateNewGasPrice(td)
+	assert.NoError(t, err)
+
+	minBump := big.NewInt(110)
+	assert.True(t, updated.GasTip.Cmp(minBump) >= 0, "tip %s was not bumped by 10%%", updated.GasTip)
+	assert.True(t, updated.BaseFee.Cmp(minBump) >= 0, "base fee %s was not bumped by 10%%", updated.BaseFee)
+
+	feeCap := new(big.Int).Add(td.BaseFee, td.GasTip)
+	updatedFeeCap := new(big.Int).Add(updated.BaseFee, updated.GasTip)
+	assert.True(t, updatedFeeCap.Cmp(minReplacementFee(feeCap)) >= 0, "fee cap %s was not bumped by 10%%", updatedFeeCap)
+}
+
+func TestValidateChainIDLegacy(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+
+	td := Delivery{UniqueID: "1", ChainID: chainId}
+	sign := func(signer types.Signer) *types.Transaction {
+		tx, err := types.SignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    1,
+			GasPrice: big.NewInt(1),
+			Gas:      21000,
+		})
+		assert.NoError(t, err)
+		return tx
+	}
+
+	// Chain ID of legacy transactions is derived from their V value.
+	assert.NoError(t, validateChainID(td, sign(types.NewEIP155Signer(big.NewInt(chainId))), false))
+	assert.ErrorIs(t, validateChainID(td, sign(types.NewEIP155Signer(big.NewInt(chainId+1))), false), ErrChainIDMismatch)
+	assert.ErrorIs(t, validateChainID(td, sign(types.HomesteadSigner{}), false), ErrChainIDMismatch)
+
+	t.Run("allow unprotected", func(t *testing.T) {
+		assert.NoError(t, validateChainID(td, sign(types.HomesteadSigner{}), true))
+		assert.NoError(t, validateChainID(td, sign(types.NewEIP155Signer(big.NewInt(chainId))), true))
+		assert.ErrorIs(t, validateChainID(td, sign(types.NewEIP155Signer(big.NewInt(chainId+1))), true), ErrChainIDMismatch)
+	})
+}
+
+func TestDepotShutdown(t *testing.T) {
+	senderAddr := common.Address{}
+	req := DeliveryRequest{
+		ChainID: chainId,
+		Sender:  senderAddr,
+		Type:    "test",
+	}
+
+	t.Run("waits for in flight deliveries", func(t *testing.T) {
+		nonceTracker := &mockNonceTracker{
+			nonces:      make(map[string]uint64),
+			confirmNone: true,
+		}
+		depot, storage := newTestDepot(t, nonceTracker, DepotConfig{MaxNonDelivered: 5})
+		depot.Run()
+
+		_, err := depot.EnqueueDelivery(req, false)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		done := make(chan error)
+		go func() {
+			done <- depot.Shutdown(ctx)
+		}()
+
+		assert.Eventually(t, func() bool {
+			_, err := depot.EnqueueDelivery(req, false)
+			return errors.Is(err, ErrShutdown)
+		}, time.Second, time.Millisecond*10)
+
+		nonceTracker.setConfirmNone(false)
+		nonceTracker.setConfirmAll(true)
+
+		assert.NoError(t, <-done)
+		assert.Equal(t, 1, storage.length())
+		assert.Equal(t, DeliveryState(DeliveryStateDelivered), storage.get(0).State)
+	})
+
+	t.Run("gives up when context is done", func(t *testing.T) {
+		depot, _ := newTestDepot(t, &mockNonceTracker{
+			nonces:      make(map[string]uint64),
+			confirmNone: true,
+		}, DepotConfig{MaxNonDelivered: 5})
+		depot.Run()
+
+		_, err := depot.EnqueueDelivery(req, false)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		assert.ErrorIs(t, depot.Shutdown(ctx), context.DeadlineExceeded)
+
+		_, err = depot.EnqueueDelivery(req, false)
+		assert.ErrorIs(t, err, ErrShutdown)
+	})
+}
+
+func TestDepotGlobalQueueLimit(t *testing.T) {
+	senders := []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")}
+	mockStorage := mockStorage{
+		deliveries: []Delivery{},
+	}
+	depot := NewDepot(&mockCourier{lastDeliveredNonce: -1}, &mockStorage, &mockNonceTracker{nonces: make(map[string]uint64)}, nil, DepotConfig{
+		MaxNonDelivered:      2,
+		MaxNonDeliveredTotal: 3,
+		Workers: []DepotWorker{
+			{Address: senders[0], ChainID: chainId},
+			{Address: senders[1], ChainID: chainId},
+		},
+	})
+
+	enqueue := func(sender common.Address, force bool) error {
+		_, err := depot.EnqueueDelivery(DeliveryRequest{
+			ChainID: chainId,
+			Sender:  sender,
+			Type:    "test",
+		}, force)
+		return err
+	}
+
+	assert.NoError(t, enqueue(senders[0], false))
+	assert.NoError(t, enqueue(senders[0], false))
+	assert.NoError(t, enqueue(senders[1], false))
+
+	err := enqueue(senders[1], false)
+	assert.ErrorIs(t, err, ErrGlobalQueueFull)
+	assert.EqualError(t, err, "global delivery queue is full: max count of 3 reached")
+
+	assert.NoError(t, enqueue(senders[1], true))
+	assert.Equal(t, 4, mockStorage.length())
+}
+
+func TestDepotEvents(t *testing.T) {
+	senderAddr := common.Address{}
+	req := DeliveryRequest{
+		ChainID: chainId,
+		Sender:  senderAddr,
+		Type:    "test",
+	}
+
+	t.Run("emits sent and delivered", func(t *testing.T) {
+		nonceTracker := &mockNonceTracker{
+			nonces:      make(map[string]uint64),
+			confirmNone: true,
+		}
+		depot, _ := newTestDepot(t, nonceTracker, DepotConfig{MaxNonDelivered: 5, EventsBuffer: 10})
+		depot.Run()
+		defer depot.Stop()
+
+		id, err := depot.EnqueueDelivery(req, false)
+		assert.NoError(t, err)
+
+		select {
+		case ev := <-depot.Events():
+			assert.Equal(t, DeliveryEventSent, ev.Type)
+			assert.Equal(t, id, ev.Delivery.UniqueID)
+			assert.Equal(t, DeliveryState(DeliveryStateSent), ev.Delivery.State)
+		case <-time.After(time.Second):
+			t.Fatal("sent event not received")
+		}
+
+		nonceTracker.setConfirmNone(false)
+		nonceTracker.setConfirmAll(true)
+
+		select {
+		case ev := <-depot.Events():
+			assert.Equal(t, DeliveryEventDelivered, ev.Type)
+			assert.Equal(t, id, ev.Delivery.UniqueID)
+			assert.Equal(t, DeliveryState(DeliveryStateDelivered), ev.Delivery.State)
+		case <-time.After(time.Second):
+			t.Fatal("delivered event not received")
+		}
+	})
+
+	t.Run("does not block without a consumer", func(t *testing.T) {
+		nonceTracker := &mockNonceTracker{
+			nonces:     make(map[string]uint64),
+			confirmAll: true,
+		}
+		depot, _ := newTestDepot(t, nonceTracker, DepotConfig{MaxNonDelivered: 5})
+		depot.Run()
+
+		_, err := depot.EnqueueDelivery(req, false)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		assert.NoError(t, depot.Shutdown(ctx))
+	})
+}
+
+func TestDepotEnqueueDeliveryWait(t *testing.T) {
+	senderAddr := common.Address{}
+	req := DeliveryRequest{
+		ChainID: chainId,
+		Sender:  senderAddr,
+		Type:    "test",
+	}
+
+	t.Run("enqueues right away if there is room", func(t *testing.T) {
+		depot, storage := newTestDepot(t, nil, DepotConfig{MaxNonDelivered: 1})
+		_, err := depot.EnqueueDeliveryWait(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, storage.length())
+	})
+
+	t.Run("waits for a free slot", func(t *testing.T) {
+		depot, storage := newTestDepot(t, nil, DepotConfig{MaxNonDelivered: 1})
+		_, err := depot.EnqueueDelivery(req, false)
+		assert.NoError(t, err)
+
+		_, err = depot.EnqueueDelivery(req, false)
+		assert.ErrorIs(t, err, ErrQueueFull)
+
+		done := make(chan error)
+		go func() {
+			_, err := depot.EnqueueDeliveryWait(context.Background(), req)
+			done <- err
+		}()
+
+		time.Sleep(enqueueWaitInterval * 2)
+		assert.Equal(t, 1, storage.length())
+
+		delivered := storage.get(0)
+		delivered.State = DeliveryStateDelivered
+		assert.NoError(t, storage.UpsertDeliveryRequest(delivered))
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("delivery was not enqueued after a slot freed up")
+		}
+		assert.Equal(t, 2, storage.length())
+	})
+
+	t.Run("gives up when context is done", func(t *testing.T) {
+		depot, _ := newTestDepot(t, nil, DepotConfig{MaxNonDelivered: 1})
+		_, err := depot.EnqueueDelivery(req, false)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), enqueueWaitInterval*2)
+		defer cancel()
+		_, err = depot.EnqueueDeliveryWait(ctx, req)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("limits waiting requests", func(t *testing.T) {
+		depot, _ := newTestDepot(t, nil, DepotConfig{MaxNonDelivered: 1, MaxWaiting: 1})
+		_, err := depot.EnqueueDelivery(req, false)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			_, err := depot.EnqueueDeliveryWait(ctx, req)
+			done <- err
+		}()
+
+		assert.Eventually(t, func() bool {
+			return depot.waiting.Load() == 1
+		}, time.Second, time.Millisecond*10)
+
+		_, err = depot.EnqueueDeliveryWait(context.Background(), req)
+		assert.ErrorIs(t, err, ErrWaitingRoomFull)
+
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+}
+
+// newTestDepot returns a depot with a single worker for the zero sender address
+// and a gas tracker which does not increase gas on its own. Workers of cfg are
+// replaced. If nonceTracker is nil, an empty one is used.
+func newTestDepot(t *testing.T, nonceTracker *mockNonceTracker, cfg DepotConfig) (*Depot, *mockStorage) {
+	if nonceTracker == nil {
+		nonceTracker = &mockNonceTracker{nonces: make(map[string]uint64)}
+	}
+
+	storage := &mockStorage{
+		deliveries: []Delivery{},
+	}
+	gasTracker := NewGasTracker(&mockGasStation{
+		defaultPrice:   big.NewInt(1),
+		defaultBaseFee: big.NewInt(1),
+	}, map[int64]GasIncreaseOpts{
+		chainId: {
+			Multiplier:       1.1,
+			PriceLimit:       big.NewInt(1000),
+			IncreaseInterval: time.Hour,
+		},
+	}, GasTrackerSpeedMedium)
+
+	cfg.Workers = []DepotWorker{
+		{
+			Address:         common.Address{},
+			ChainID:         chainId,
+			ProcessInterval: time.Millisecond * 10,
+			ProcessCount:    3,
+		},
+	}
+	return NewDepot(&mockCourier{lastDeliveredNonce: -1}, storage, nonceTracker, gasTracker, cfg), storage
+}
+
 type mockStorage struct {
 	deliveries []Delivery
 	lock       sync.Mutex
@@ -612,7 +1188,16 @@ func (m *mockStorage) reset() {
 type mockCourier struct {
 	lastDeliveredNonce int64
 	calls              uint64
-	lock               sync.Mutex
+	undeliverable      DeliverableType
+	signChainID        int64
+	// unprotected makes the courier deliver legacy transactions
+	// without replay protection.
+	unprotected bool
+	// skipWriteRequest makes the courier sign without `ToWriteRequest`,
+	// so transactions are only checked after they were delivered.
+	skipWriteRequest bool
+	sent             []*types.Transaction
+	lock             sync.Mutex
 }
 
 func (m *mockCourier) DeliverTransaction(tx Delivery) (*types.Transaction, error) {
@@ -622,17 +1207,42 @@ func (m *mockCourier) DeliverTransaction(tx Delivery) (*types.Transaction, error
 		m.lastDeliveredNonce = int64(tx.Nonce)
 	}
 	m.calls += 1
-	return types.NewTx(&types.DynamicFeeTx{
-		ChainID:   big.NewInt(tx.ChainID),
+	chainID := tx.ChainID
+	if m.signChainID != 0 {
+		chainID = m.signChainID
+	}
+	unsigned := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chainID),
 		Nonce:     tx.Nonce,
 		GasTipCap: tx.GasTip,
 		GasFeeCap: tx.BaseFee,
 		Gas:       tx.GasPrice.Uint64(),
-	}), nil
+	})
+	if m.unprotected {
+		unsigned = types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce,
+			GasPrice: tx.GasTip,
+			Gas:      tx.GasPrice.Uint64(),
+		})
+	}
+	if m.skipWriteRequest {
+		m.sent = append(m.sent, unsigned)
+		return unsigned, nil
+	}
+
+	wr := tx.ToWriteRequest(func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx, nil
+	}, 0)
+	signed, err := wr.Signer(tx.Sender, unsigned)
+	if err != nil {
+		return nil, err
+	}
+	m.sent = append(m.sent, signed)
+	return signed, nil
 }
 
-func (m *mockCourier) CanDeliver(DeliverableType) bool {
-	return true
+func (m *mockCourier) CanDeliver(typ DeliverableType) bool {
+	return m.undeliverable == "" || typ != m.undeliverable
 }
 
 func (m *mockCourier) reset() {
@@ -648,6 +1258,18 @@ func (m *mockCourier) getLastDeliveredNonce() int64 {
 	return m.lastDeliveredNonce
 }
 
+func (m *mockCourier) setSignChainID(chainID int64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.signChainID = chainID
+}
+
+func (m *mockCourier) getSent() []*types.Transaction {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.sent
+}
+
 func (m *mockCourier) getCalls() uint64 {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -0,0 +1,50 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package transaction
+
+// DeliveryEventType describes what happened to a delivery.
+type DeliveryEventType string
+
+const (
+	// DeliveryEventSent is emitted when a delivery is sent out for the first time.
+	DeliveryEventSent DeliveryEventType = "sent"
+	// DeliveryEventResent is emitted when a sent delivery is sent out again,
+	// either with bumped gas or because it was forced after `ForceResend`.
+	DeliveryEventResent DeliveryEventType = "resent"
+	// DeliveryEventDelivered is emitted when a delivery is confirmed and no longer tracked.
+	DeliveryEventDelivered DeliveryEventType = "delivered"
+)
+
+// DeliveryEvent is emitted by the depot on every state change of a delivery.
+type DeliveryEvent struct {
+	Type     DeliveryEventType
+	Delivery Delivery
+}
+
+// Events returns a channel on which delivery events are published.
+// Events are dropped if the channel buffer, set by `DepotConfig.EventsBuffer`,
+// is full so a depot is never blocked by a slow or missing consumer.
+func (d *Depot) Events() <-chan DeliveryEvent {
+	return d.events
+}
+
+func (d *Depot) emit(t DeliveryEventType, td Delivery) {
+	select {
+	case d.events <- DeliveryEvent{Type: t, Delivery: td}:
+	default:
+	}
+}
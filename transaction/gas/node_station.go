@@ -1,6 +1,7 @@
 package gas
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
@@ -9,12 +10,28 @@ import (
 )
 
 type NodeStation struct {
-	bc      BCClient
-	chainID int64
+	bc         BCClient
+	chainID    int64
+	multiplier float64
+
+	priorityFee PriorityFeeOracle
 }
 
 func NewNodeStation(bc BCClient, chainID int64) *NodeStation {
-	return &NodeStation{bc: bc, chainID: chainID}
+	return NewNodeStationWithMultiplier(bc, chainID, 1)
+}
+
+// NewNodeStationWithMultiplier returns a node station which multiplies
+// the gas price suggested by the node with a given multiplier.
+func NewNodeStationWithMultiplier(bc BCClient, chainID int64, multiplier float64) *NodeStation {
+	return &NodeStation{bc: bc, chainID: chainID, multiplier: multiplier}
+}
+
+// NewNodeStationWithPriorityFee returns a node station which suggests
+// the tip of EIP-1559 transactions using the given priority fee oracle
+// instead of the legacy gas price suggested by the node.
+func NewNodeStationWithPriorityFee(bc BCClient, chainID int64, oracle PriorityFeeOracle) *NodeStation {
+	return &NodeStation{bc: bc, chainID: chainID, multiplier: 1, priorityFee: oracle}
 }
 
 type BCClient interface {
@@ -24,10 +41,11 @@ type BCClient interface {
 }
 
 func (n *NodeStation) GetGasPrices() (*GasPrices, error) {
-	suggestGasPrice, err := n.bc.SuggestGasPrice(n.chainID)
+	suggestGasPrice, err := n.suggestTip()
 	if err != nil {
 		return nil, err
 	}
+	suggestGasPrice = n.applyMultiplier(suggestGasPrice)
 
 	header, err := n.bc.HeaderByNumber(n.chainID, nil)
 	if err != nil {
@@ -43,3 +61,23 @@ func (n *NodeStation) GetGasPrices() (*GasPrices, error) {
 		BaseFee: baseFee,
 	}, nil
 }
+
+func (n *NodeStation) suggestTip() (*big.Int, error) {
+	if n.priorityFee != nil {
+		return n.priorityFee.Suggest(context.Background(), n.chainID)
+	}
+
+	return n.bc.SuggestGasPrice(n.chainID)
+}
+
+func (n *NodeStation) applyMultiplier(price *big.Int) *big.Int {
+	if n.multiplier <= 0 || n.multiplier == 1 {
+		return price
+	}
+
+	res, _ := new(big.Float).Mul(
+		big.NewFloat(n.multiplier),
+		new(big.Float).SetInt(price),
+	).Int(nil)
+	return res
+}
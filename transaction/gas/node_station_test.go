@@ -46,3 +46,65 @@ func TestNodeStation(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestNodeStationWithMultiplier(t *testing.T) {
+	cl := &mocks.EtherClientMock{
+		SuggestGasPriceFunc: func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(200), nil
+		},
+		HeaderByNumberFunc: func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			return &types.Header{
+				Number: big.NewInt(20),
+			}, nil
+		},
+	}
+	getter := client.NewDefaultAddressableEthClientGetter("", cl)
+	mbc := client.NewMultichainBlockchainClient(map[int64]client.BC{
+		1: client.NewBlockchain(getter, time.Second),
+	})
+
+	for _, test := range []struct {
+		multiplier float64
+		price      *big.Int
+	}{
+		{multiplier: 1.5, price: big.NewInt(300)},
+		{multiplier: 0.5, price: big.NewInt(100)},
+		{multiplier: 1, price: big.NewInt(200)},
+		{multiplier: 0, price: big.NewInt(200)},
+	} {
+		gp, err := NewNodeStationWithMultiplier(mbc, 1, test.multiplier).GetGasPrices()
+		assert.NoError(t, err)
+		assert.Equal(t, test.price, gp.SafeLow)
+		assert.Equal(t, test.price, gp.Average)
+		assert.Equal(t, test.price, gp.Fast)
+	}
+}
+
+func TestNodeStationWithPriorityFee(t *testing.T) {
+	cl := &mocks.EtherClientMock{
+		SuggestGasPriceFunc: func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(200), nil
+		},
+		SuggestGasTipCapFunc: func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(5), nil
+		},
+		HeaderByNumberFunc: func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			return &types.Header{
+				Number: big.NewInt(20),
+			}, nil
+		},
+	}
+	getter := client.NewDefaultAddressableEthClientGetter("", cl)
+	mbc := client.NewMultichainBlockchainClient(map[int64]client.BC{
+		1: client.NewBlockchain(getter, time.Second),
+	})
+	oracle := NewNodePriorityFeeOracle(time.Second, map[int64]TipCapSuggester{1: cl}, nil)
+
+	gp, err := NewNodeStationWithPriorityFee(mbc, 1, oracle).GetGasPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), gp.SafeLow)
+	assert.Equal(t, big.NewInt(5), gp.Average)
+	assert.Equal(t, big.NewInt(5), gp.Fast)
+	assert.Equal(t, big.NewInt(1000000000), gp.BaseFee)
+	assert.Len(t, cl.SuggestGasPriceCalls(), 0)
+}
@@ -0,0 +1,72 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// PriorityFeeOracle suggests a max priority fee per gas (tip) for EIP-1559 transactions.
+type PriorityFeeOracle interface {
+	Suggest(ctx context.Context, chainID int64) (*big.Int, error)
+}
+
+// TipCapSuggester is implemented by ethereum clients which support `eth_maxPriorityFeePerGas`.
+type TipCapSuggester interface {
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// rpcMethodNotFound is the JSON-RPC error code returned for unknown methods.
+const rpcMethodNotFound = -32601
+
+// NodePriorityFeeOracle asks the node for the priority fee using `eth_maxPriorityFeePerGas`.
+// If the node does not support the method, a static fallback value is returned instead.
+type NodePriorityFeeOracle struct {
+	timeout  time.Duration
+	clients  map[int64]TipCapSuggester
+	fallback *big.Int
+}
+
+// NewNodePriorityFeeOracle returns a new priority fee oracle using a client per chain.
+func NewNodePriorityFeeOracle(timeout time.Duration, clients map[int64]TipCapSuggester, fallback *big.Int) *NodePriorityFeeOracle {
+	return &NodePriorityFeeOracle{
+		timeout:  timeout,
+		clients:  clients,
+		fallback: fallback,
+	}
+}
+
+// Suggest returns the priority fee suggested by the node of the given chain.
+func (o *NodePriorityFeeOracle) Suggest(ctx context.Context, chainID int64) (*big.Int, error) {
+	c, ok := o.clients[chainID]
+	if !ok {
+		return nil, fmt.Errorf("no client for chain %d", chainID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	tip, err := c.SuggestGasTipCap(ctx)
+	if err != nil {
+		if isMethodNotSupported(err) && o.fallback != nil {
+			return new(big.Int).Set(o.fallback), nil
+		}
+		return nil, fmt.Errorf("could not get priority fee for chain %d: %w", chainID, err)
+	}
+
+	return tip, nil
+}
+
+func isMethodNotSupported(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+
+	return rpcErr.ErrorCode() == rpcMethodNotFound || strings.Contains(strings.ToLower(rpcErr.Error()), "not supported")
+}
@@ -0,0 +1,65 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/payments/v3/client/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type rpcError struct {
+	code int
+	msg  string
+}
+
+func (e rpcError) Error() string  { return e.msg }
+func (e rpcError) ErrorCode() int { return e.code }
+
+func TestNodePriorityFeeOracle(t *testing.T) {
+	fallback := big.NewInt(30)
+	newOracle := func(err error) *NodePriorityFeeOracle {
+		cl := &mocks.EtherClientMock{
+			SuggestGasTipCapFunc: func(ctx context.Context) (*big.Int, error) {
+				if err != nil {
+					return nil, err
+				}
+				return big.NewInt(2), nil
+			},
+		}
+		return NewNodePriorityFeeOracle(time.Second, map[int64]TipCapSuggester{1: cl}, fallback)
+	}
+
+	t.Run("suggests node tip", func(t *testing.T) {
+		tip, err := newOracle(nil).Suggest(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(2), tip)
+	})
+
+	t.Run("falls back if method is not supported", func(t *testing.T) {
+		for _, nodeErr := range []error{
+			rpcError{code: -32601, msg: "the method eth_maxPriorityFeePerGas does not exist/is not available"},
+			rpcError{code: -32000, msg: "Method not supported"},
+		} {
+			tip, err := newOracle(nodeErr).Suggest(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, fallback, tip)
+		}
+	})
+
+	t.Run("returns other errors", func(t *testing.T) {
+		_, err := newOracle(errors.New("connection refused")).Suggest(context.Background(), 1)
+		assert.Error(t, err)
+
+		_, err = newOracle(rpcError{code: -32000, msg: "internal error"}).Suggest(context.Background(), 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("fails on unknown chain", func(t *testing.T) {
+		_, err := newOracle(nil).Suggest(context.Background(), 2)
+		assert.Error(t, err)
+	})
+}
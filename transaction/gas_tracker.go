@@ -25,6 +25,27 @@ type GasIncreaseOpts struct {
 	OverpayByMul     float64
 }
 
+// Validate checks that the opts can be used to increase gas prices.
+func (o GasIncreaseOpts) Validate() error {
+	if o.Multiplier < 1 {
+		return fmt.Errorf("multiplier must be >= 1, got %v", o.Multiplier)
+	}
+	if o.PriceLimit == nil {
+		return errors.New("price limit must be set")
+	}
+	if o.PriceLimit.Sign() <= 0 {
+		return fmt.Errorf("price limit must be > 0, got %s", o.PriceLimit)
+	}
+	if o.IncreaseInterval < 0 {
+		return fmt.Errorf("increase interval must be >= 0, got %s", o.IncreaseInterval)
+	}
+	if len(o.OverpayFor) > 0 && o.OverpayByMul < 1 {
+		return fmt.Errorf("overpay multiplier must be >= 1 when overpay types are set, got %v", o.OverpayByMul)
+	}
+
+	return nil
+}
+
 type fees struct {
 	Base *big.Int
 	Tip  *big.Int
@@ -42,7 +63,13 @@ const (
 	GasTrackerSpeedFast   GasTrackerSpeed = "fast"
 )
 
-var errMaxPriceReached = errors.New("max price reached, cannot increase")
+// ErrGasPriceCapped is returned when the fees of a delivery can no longer
+// be increased enough to replace it without exceeding the configured `PriceLimit`.
+var ErrGasPriceCapped = errors.New("max price reached, cannot increase")
+
+// minReplacementBumpPercent is the minimal fee increase that nodes
+// require to accept a replacement transaction with the same nonce.
+const minReplacementBumpPercent = 10
 
 func NewGasTracker(gs GasStation, opts map[int64]GasIncreaseOpts, speed GasTrackerSpeed) *GasTracker {
 	if speed == "" {
@@ -56,10 +83,22 @@ func NewGasTracker(gs GasStation, opts map[int64]GasIncreaseOpts, speed GasTrack
 	}
 }
 
+// NewGasTrackerWithValidation returns a new gas tracker same as `NewGasTracker`,
+// but returns an error if the opts of any chain are invalid.
+func NewGasTrackerWithValidation(gs GasStation, opts map[int64]GasIncreaseOpts, speed GasTrackerSpeed) (*GasTracker, error) {
+	for chainID, o := range opts {
+		if err := o.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid gas increase opts for chain %d: %w", chainID, err)
+		}
+	}
+
+	return NewGasTracker(gs, opts, speed), nil
+}
+
 func (g *GasTracker) CanReceiveMoreGas(chainID int64, lastFillUpUTC time.Time) (bool, error) {
-	opts, ok := g.opts[chainID]
-	if !ok {
-		return false, fmt.Errorf("no opts for chain %d", chainID)
+	opts, err := g.getOpts(chainID)
+	if err != nil {
+		return false, err
 	}
 
 	receiveAfter := lastFillUpUTC.Add(opts.IncreaseInterval)
@@ -96,9 +135,9 @@ func (g *GasTracker) RecalculateDeliveryGas(chainID int64, lastKnownTip *big.Int
 		return g.ReceiveInitialGas(chainID, txType)
 	}
 
-	opts, ok := g.opts[chainID]
-	if !ok {
-		return nil, fmt.Errorf("no opts for chain %d", chainID)
+	opts, err := g.getOpts(chainID)
+	if err != nil {
+		return nil, err
 	}
 
 	newTip := g.calculateNewPrice(chainID, lastKnownTip, opts.Multiplier)
@@ -112,16 +151,22 @@ func (g *GasTracker) RecalculateDeliveryGas(chainID int64, lastKnownTip *big.Int
 		newTip = newFees.Tip
 	}
 
-	// Check that new tip is not exceeding our limits.
+	// Make sure the replacement will not be rejected as underpriced.
+	if minTip := minReplacementFee(lastKnownTip); newTip.Cmp(minTip) < 0 {
+		newTip = minTip
+	}
+
+	// Check that new tip is not exceeding our limits. Capping it is only
+	// useful if the capped tip is still enough to replace the transaction.
 	if newTip.Cmp(opts.PriceLimit) > 0 {
-		if lastKnownTip.Cmp(opts.PriceLimit) < 0 {
+		if opts.PriceLimit.Cmp(minReplacementFee(lastKnownTip)) >= 0 {
 			return &fees{
 				Base: newFees.Base,
 				Tip:  g.calculateOverpay(chainID, txType, opts.PriceLimit),
 			}, nil
 		}
 
-		return nil, errMaxPriceReached
+		return nil, ErrGasPriceCapped
 	}
 
 	return &fees{
@@ -130,6 +175,15 @@ func (g *GasTracker) RecalculateDeliveryGas(chainID int64, lastKnownTip *big.Int
 	}, nil
 }
 
+func (g *GasTracker) getOpts(chainID int64) (GasIncreaseOpts, error) {
+	opts, ok := g.opts[chainID]
+	if !ok {
+		return GasIncreaseOpts{}, fmt.Errorf("no opts for chain %d", chainID)
+	}
+
+	return opts, nil
+}
+
 func (g *GasTracker) calculateOverpay(chainID int64, txType DeliverableType, price *big.Int) *big.Int {
 	opts, ok := g.opts[chainID]
 	if !ok {
@@ -167,3 +221,13 @@ func (g *GasTracker) calculateNewPrice(chainID int64, lastKnownTip *big.Int, mul
 
 	return newTip
 }
+
+// minReplacementFee returns the lowest fee that is at least
+// `minReplacementBumpPercent` higher than the given one.
+func minReplacementFee(fee *big.Int) *big.Int {
+	bump := new(big.Int).Mul(fee, big.NewInt(minReplacementBumpPercent))
+	bump.Add(bump, big.NewInt(99))
+	bump.Div(bump, big.NewInt(100))
+
+	return new(big.Int).Add(fee, bump)
+}
@@ -3,6 +3,7 @@ package transaction
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -117,3 +118,182 @@ func Test_calculateOverpay(t *testing.T) {
 		})
 	}
 }
+
+func Test_RecalculateDeliveryGasBumpsAtLeastTenPercent(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		multiplier float64
+		lastTip    *big.Int
+		networkTip *big.Int
+
+		getTip *big.Int
+	}{
+		{
+			name:       "multiplier below minimal bump is raised to 10%",
+			multiplier: 1.01,
+			lastTip:    big.NewInt(1000),
+			networkTip: big.NewInt(1),
+			getTip:     big.NewInt(1100),
+		},
+		{
+			name:       "minimal bump is rounded up",
+			multiplier: 1.01,
+			lastTip:    big.NewInt(1),
+			networkTip: big.NewInt(1),
+			getTip:     big.NewInt(2),
+		},
+		{
+			name:       "multiplier above minimal bump is respected",
+			multiplier: 1.5,
+			lastTip:    big.NewInt(1000),
+			networkTip: big.NewInt(1),
+			getTip:     big.NewInt(1500),
+		},
+		{
+			name:       "network tip is used if it is higher",
+			multiplier: 1.01,
+			lastTip:    big.NewInt(1000),
+			networkTip: big.NewInt(2000),
+			getTip:     big.NewInt(2000),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			gt := NewGasTracker(&mockGasStation{
+				defaultPrice:   test.networkTip,
+				defaultBaseFee: big.NewInt(1),
+			}, map[int64]GasIncreaseOpts{
+				1: {
+					Multiplier: test.multiplier,
+					PriceLimit: big.NewInt(100000),
+				},
+			}, GasTrackerSpeedMedium)
+
+			got, err := gt.RecalculateDeliveryGas(1, test.lastTip, "")
+			assert.NoError(t, err)
+			assert.Equal(t, test.getTip, got.Tip)
+		})
+	}
+}
+
+func Test_GasIncreaseOptsValidate(t *testing.T) {
+	valid := func() GasIncreaseOpts {
+		return GasIncreaseOpts{
+			Multiplier:       1.1,
+			PriceLimit:       big.NewInt(1000),
+			IncreaseInterval: time.Minute,
+			OverpayFor:       []DeliverableType{"settle"},
+			OverpayByMul:     1.5,
+		}
+	}
+
+	for _, test := range []struct {
+		name   string
+		modify func(o *GasIncreaseOpts)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(o *GasIncreaseOpts) {},
+		},
+		{
+			name:   "zero multiplier",
+			modify: func(o *GasIncreaseOpts) { o.Multiplier = 0 },
+			err:    "multiplier must be >= 1, got 0",
+		},
+		{
+			name:   "decreasing multiplier",
+			modify: func(o *GasIncreaseOpts) { o.Multiplier = 0.9 },
+			err:    "multiplier must be >= 1, got 0.9",
+		},
+		{
+			name:   "missing price limit",
+			modify: func(o *GasIncreaseOpts) { o.PriceLimit = nil },
+			err:    "price limit must be set",
+		},
+		{
+			name:   "zero price limit",
+			modify: func(o *GasIncreaseOpts) { o.PriceLimit = big.NewInt(0) },
+			err:    "price limit must be > 0, got 0",
+		},
+		{
+			name:   "negative increase interval",
+			modify: func(o *GasIncreaseOpts) { o.IncreaseInterval = -time.Second },
+			err:    "increase interval must be >= 0, got -1s",
+		},
+		{
+			name:   "overpay multiplier missing",
+			modify: func(o *GasIncreaseOpts) { o.OverpayByMul = 0 },
+			err:    "overpay multiplier must be >= 1 when overpay types are set, got 0",
+		},
+		{
+			name: "overpay multiplier ignored without types",
+			modify: func(o *GasIncreaseOpts) {
+				o.OverpayFor = nil
+				o.OverpayByMul = 0
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			opts := valid()
+			test.modify(&opts)
+
+			err := opts.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.err)
+		})
+	}
+
+	t.Run("tracker with validation refuses invalid opts", func(t *testing.T) {
+		gs := &mockGasStation{
+			defaultPrice:   big.NewInt(1),
+			defaultBaseFee: big.NewInt(1),
+		}
+		_, err := NewGasTrackerWithValidation(gs, map[int64]GasIncreaseOpts{
+			1: {Multiplier: 1.1},
+		}, GasTrackerSpeedMedium)
+		assert.EqualError(t, err, "invalid gas increase opts for chain 1: price limit must be set")
+
+		gt, err := NewGasTrackerWithValidation(gs, map[int64]GasIncreaseOpts{
+			1: valid(),
+		}, GasTrackerSpeedMedium)
+		assert.NoError(t, err)
+
+		_, err = gt.CanReceiveMoreGas(2, time.Now())
+		assert.EqualError(t, err, "no opts for chain 2")
+	})
+
+	t.Run("tracker without validation accepts any opts", func(t *testing.T) {
+		gt := NewGasTracker(&mockGasStation{}, map[int64]GasIncreaseOpts{
+			1: {Multiplier: 0.5},
+		}, GasTrackerSpeedMedium)
+
+		_, err := gt.CanReceiveMoreGas(1, time.Now())
+		assert.NoError(t, err)
+	})
+}
+
+func Test_RecalculateDeliveryGasCapped(t *testing.T) {
+	gt := NewGasTracker(&mockGasStation{
+		defaultPrice:   big.NewInt(1),
+		defaultBaseFee: big.NewInt(1),
+	}, map[int64]GasIncreaseOpts{
+		1: {
+			Multiplier: 1.5,
+			PriceLimit: big.NewInt(1000),
+		},
+	}, GasTrackerSpeedMedium)
+
+	got, err := gt.RecalculateDeliveryGas(1, big.NewInt(800), "")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), got.Tip)
+
+	_, err = gt.RecalculateDeliveryGas(1, big.NewInt(1000), "")
+	assert.ErrorIs(t, err, ErrGasPriceCapped)
+
+	// Capping to the limit would not bump the tip enough to replace it.
+	_, err = gt.RecalculateDeliveryGas(1, big.NewInt(950), "")
+	assert.ErrorIs(t, err, ErrGasPriceCapped)
+}
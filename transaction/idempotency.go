@@ -0,0 +1,61 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package transaction
+
+import "sync"
+
+// DepotIdempotencyStore keeps track of delivery requests enqueued
+// with an idempotency key so that they are not enqueued twice.
+type DepotIdempotencyStore interface {
+	// GetUniqueID should return the unique ID of a delivery enqueued with
+	// the given key or an empty string if no such delivery exists.
+	GetUniqueID(key string) (string, error)
+	// SetUniqueID should remember the unique ID of a delivery enqueued with the given key.
+	SetUniqueID(key, uniqueID string) error
+}
+
+// InMemoryIdempotencyStore is the default idempotency store.
+// Keys are never evicted and are lost on restart, use a persistent
+// store if that is not acceptable.
+type InMemoryIdempotencyStore struct {
+	ids  map[string]string
+	lock sync.Mutex
+}
+
+// NewInMemoryIdempotencyStore returns a new in memory idempotency store.
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{
+		ids: make(map[string]string),
+	}
+}
+
+// GetUniqueID returns the unique ID stored for the given key.
+func (s *InMemoryIdempotencyStore) GetUniqueID(key string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.ids[key], nil
+}
+
+// SetUniqueID stores the unique ID for the given key.
+func (s *InMemoryIdempotencyStore) SetUniqueID(key, uniqueID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ids[key] = uniqueID
+	return nil
+}
@@ -0,0 +1,39 @@
+/* Mysterium network payment library.
+ *
+ * Copyright (C) 2021 BlockDev AG
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package transaction
+
+// TransactionLogger receives non critical events that happen
+// during transaction handling and will be eventually handled.
+type TransactionLogger interface {
+	Log(level string, msg string, err error)
+}
+
+const (
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// FuncLogger adapts a plain error func to the `TransactionLogger` interface.
+// Level and message are dropped, only the error is passed on.
+type FuncLogger func(err error)
+
+// Log calls the underlying func with the given error.
+func (fn FuncLogger) Log(_ string, _ string, err error) {
+	if fn != nil {
+		fn(err)
+	}
+}
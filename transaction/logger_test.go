@@ -0,0 +1,49 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncLogger(t *testing.T) {
+	var got error
+	l := FuncLogger(func(err error) {
+		got = err
+	})
+
+	want := errors.New("boom")
+	l.Log(LogLevelError, "some context", want)
+	assert.Equal(t, want, got)
+
+	assert.NotPanics(t, func() {
+		FuncLogger(nil).Log(LogLevelWarn, "", want)
+	})
+}
+
+type recordingLogger struct {
+	levels []string
+	msgs   []string
+	errs   []error
+}
+
+func (r *recordingLogger) Log(level string, msg string, err error) {
+	r.levels = append(r.levels, level)
+	r.msgs = append(r.msgs, msg)
+	r.errs = append(r.errs, err)
+}
+
+func TestDepotTransactionLogger(t *testing.T) {
+	depot := NewDepot(&mockCourier{}, &mockStorage{}, &mockNonceTracker{}, nil, DepotConfig{})
+
+	l := &recordingLogger{}
+	depot.AttachTransactionLogger(l)
+
+	// Invalid cleanup config is reported right away.
+	depot.startCleanupThread()
+
+	assert.Equal(t, []string{LogLevelError}, l.levels)
+	assert.Equal(t, []string{"cleaner not started"}, l.msgs)
+	assert.EqualError(t, l.errs[0], "invalid cleanup config")
+}
@@ -1,8 +1,10 @@
 package transaction
 
 import (
+	"context"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -12,10 +14,23 @@ type NonceTracker struct {
 	nonceTrackerBC nonceTrackerBC
 	ds             DepotStorage
 
-	nonces    map[Sender]uint64
+	nonces    map[Sender]cachedNonce
+	nonceTTL  time.Duration
+	noCache   bool
 	nonceLock sync.Mutex
 }
 
+type cachedNonce struct {
+	nonce     uint64
+	updatedAt time.Time
+	// prefetched marks nonces which were loaded ahead of time
+	// and not yet issued, the nonce itself is the next one to use.
+	prefetched bool
+}
+
+// prefetchConcurrency limits the amount of parallel nonce lookups in `PrefetchNonces`.
+const prefetchConcurrency = 8
+
 type nonceTrackerBC interface {
 	PendingNonceAt(chainID int64, account common.Address) (uint64, error)
 	NonceAt(chainID int64, account common.Address, blockNum *big.Int) (uint64, error)
@@ -23,13 +38,30 @@ type nonceTrackerBC interface {
 
 // NewNonceTracker returns a new nonce tracker.
 func NewNonceTracker(nonceTrackerBC nonceTrackerBC, ds DepotStorage) *NonceTracker {
+	return NewNonceTrackerWithTTL(nonceTrackerBC, ds, 0)
+}
+
+// NewNonceTrackerWithTTL returns a new nonce tracker which treats cached
+// nonces older than the given ttl as absent and reloads them.
+// Zero ttl means that cached nonces never expire.
+func NewNonceTrackerWithTTL(nonceTrackerBC nonceTrackerBC, ds DepotStorage, ttl time.Duration) *NonceTracker {
 	return &NonceTracker{
 		nonceTrackerBC: nonceTrackerBC,
-		nonces:         make(map[Sender]uint64),
+		nonces:         make(map[Sender]cachedNonce),
+		nonceTTL:       ttl,
 		ds:             ds,
 	}
 }
 
+// NewNonceTrackerWithoutCache returns a new nonce tracker which never caches nonces
+// and loads the next nonce from the storage and the BC on every call.
+// Useful when multiple processes share the same sender key.
+func NewNonceTrackerWithoutCache(nonceTrackerBC nonceTrackerBC, ds DepotStorage) *NonceTracker {
+	nt := NewNonceTracker(nonceTrackerBC, ds)
+	nt.noCache = true
+	return nt
+}
+
 type nonceSetFn func(nonce uint64) error
 
 // GetNextNonce returns an atomically increasing nonce for the account.
@@ -37,17 +69,121 @@ func (nt *NonceTracker) SetNextNonce(chainID int64, account common.Address, fn n
 	nt.nonceLock.Lock()
 	defer nt.nonceLock.Unlock()
 
+	if nt.noCache {
+		nonce, err := nt.loadNextNonce(chainID, account)
+		if err != nil {
+			return err
+		}
+		return fn(nonce)
+	}
+
 	key := NewSender(account, chainID)
-	if v, ok := nt.nonces[key]; ok {
-		v++
-		return nt.setWithExec(key, v, fn)
+	if v, ok := nt.nonces[key]; ok && !nt.isExpired(v) {
+		if v.prefetched {
+			return nt.setWithExec(key, v.nonce, fn)
+		}
+		return nt.setWithExec(key, v.nonce+1, fn)
 	}
 
-	lastKnown, err := nt.ds.GetLastQueuedDelivery(chainID, account)
+	nonce, err := nt.loadNextNonce(chainID, account)
 	if err != nil {
 		return err
 	}
 
+	return nt.setWithExec(key, nonce, fn)
+}
+
+// PrefetchNonces loads nonces for the given accounts in parallel and caches them,
+// so that the first `SetNextNonce` call for each of them does not need to reach out to the BC.
+// Accounts which already have a cached nonce are skipped.
+// It does nothing if the nonce cache is disabled.
+func (nt *NonceTracker) PrefetchNonces(ctx context.Context, chainID int64, accounts []common.Address) error {
+	if nt.noCache {
+		return nil
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, prefetchConcurrency)
+	setErr := func(err error) {
+		errOnce.Do(func() { firstErr = err })
+	}
+
+loop:
+	for _, account := range accounts {
+		if err := ctx.Err(); err != nil {
+			setErr(err)
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			setErr(ctx.Err())
+			break loop
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(account common.Address) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := nt.prefetchNonce(chainID, account); err != nil {
+				setErr(err)
+			}
+		}(account)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+func (nt *NonceTracker) prefetchNonce(chainID int64, account common.Address) error {
+	key := NewSender(account, chainID)
+	if nt.isCached(key) {
+		return nil
+	}
+
+	nonce, err := nt.loadNextNonce(chainID, account)
+	if err != nil {
+		return err
+	}
+
+	nt.nonceLock.Lock()
+	defer nt.nonceLock.Unlock()
+
+	// Nonce might have been issued while we were loading it, keep that one.
+	if v, ok := nt.nonces[key]; ok && !nt.isExpired(v) {
+		return nil
+	}
+
+	nt.nonces[key] = cachedNonce{
+		nonce:      nonce,
+		updatedAt:  time.Now(),
+		prefetched: true,
+	}
+	return nil
+}
+
+func (nt *NonceTracker) isCached(key Sender) bool {
+	nt.nonceLock.Lock()
+	defer nt.nonceLock.Unlock()
+
+	v, ok := nt.nonces[key]
+	return ok && !nt.isExpired(v)
+}
+
+// loadNextNonce returns the next nonce to be used for the account
+// taking into account both the persistent storage and the BC.
+func (nt *NonceTracker) loadNextNonce(chainID int64, account common.Address) (uint64, error) {
+	lastKnown, err := nt.ds.GetLastQueuedDelivery(chainID, account)
+	if err != nil {
+		return 0, err
+	}
+
 	persistentNonce := uint64(0)
 	if lastKnown != nil {
 		persistentNonce = lastKnown.Nonce + 1
@@ -55,7 +191,7 @@ func (nt *NonceTracker) SetNextNonce(chainID int64, account common.Address, fn n
 
 	bcNonce, err := nt.nonceTrackerBC.PendingNonceAt(chainID, account)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	nonce := persistentNonce
@@ -65,7 +201,7 @@ func (nt *NonceTracker) SetNextNonce(chainID int64, account common.Address, fn n
 		nonce = bcNonce
 	}
 
-	return nt.setWithExec(key, nonce, fn)
+	return nonce, nil
 }
 
 func (nt *NonceTracker) setWithExec(key Sender, nonce uint64, fn nonceSetFn) error {
@@ -73,10 +209,21 @@ func (nt *NonceTracker) setWithExec(key Sender, nonce uint64, fn nonceSetFn) err
 		return err
 	}
 
-	nt.nonces[key] = nonce
+	nt.nonces[key] = cachedNonce{
+		nonce:     nonce,
+		updatedAt: time.Now(),
+	}
 	return nil
 }
 
+func (nt *NonceTracker) isExpired(v cachedNonce) bool {
+	if nt.nonceTTL <= 0 {
+		return false
+	}
+
+	return time.Since(v.updatedAt) > nt.nonceTTL
+}
+
 func (nt *NonceTracker) GetConfirmedNonce(chainID int64, account common.Address) (uint64, error) {
 	bcNonce, err := nt.nonceTrackerBC.NonceAt(chainID, account, nil)
 	if err != nil {
@@ -3,6 +3,7 @@ package transaction
 import (
 	"context"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -102,3 +103,180 @@ func TestNonceTracker(t *testing.T) {
 		assert.Equal(t, 23, int(nonce))
 	})
 }
+
+// nextNonce sets and returns the next nonce of the account.
+func nextNonce(t *testing.T, nt *NonceTracker, chainID int64, account common.Address) uint64 {
+	var nonce uint64
+	err := nt.SetNextNonce(chainID, account, func(n uint64) error {
+		nonce = n
+		return nil
+	})
+	assert.NoError(t, err)
+	return nonce
+}
+
+func TestNonceTrackerSeparatesChains(t *testing.T) {
+	newMockClient := func(pending uint64) client.BC {
+		cl := &mocks.EtherClientMock{
+			PendingNonceAtFunc: func(ctx context.Context, address common.Address) (uint64, error) {
+				return pending, nil
+			},
+		}
+		return client.NewBlockchain(client.NewDefaultAddressableEthClientGetter("", cl), time.Second)
+	}
+
+	mbc := client.NewMultichainBlockchainClient(map[int64]client.BC{
+		1:   newMockClient(10),
+		137: newMockClient(500),
+	})
+	nt := NewNonceTracker(mbc, &mockStorage{deliveries: []Delivery{}})
+
+	sender := common.HexToAddress("0x1")
+	assert.Equal(t, 10, int(nextNonce(t, nt, 1, sender)))
+	assert.Equal(t, 500, int(nextNonce(t, nt, 137, sender)))
+	assert.Equal(t, 11, int(nextNonce(t, nt, 1, sender)))
+	assert.Equal(t, 501, int(nextNonce(t, nt, 137, sender)))
+	assert.Equal(t, 502, int(nextNonce(t, nt, 137, sender)))
+	assert.Equal(t, 12, int(nextNonce(t, nt, 1, sender)))
+
+	nt.ForceReloadNonce(137, sender)
+	assert.Equal(t, 13, int(nextNonce(t, nt, 1, sender)))
+	assert.Equal(t, 500, int(nextNonce(t, nt, 137, sender)))
+}
+
+func TestNonceTrackerTTL(t *testing.T) {
+	var pending uint64 = 7
+	cl := &mocks.EtherClientMock{
+		PendingNonceAtFunc: func(ctx context.Context, address common.Address) (uint64, error) {
+			return pending, nil
+		},
+	}
+	mbc := client.NewMultichainBlockchainClient(map[int64]client.BC{
+		1: client.NewBlockchain(client.NewDefaultAddressableEthClientGetter("", cl), time.Second),
+	})
+	nt := NewNonceTrackerWithTTL(mbc, &mockStorage{deliveries: []Delivery{}}, time.Millisecond*50)
+
+	sender := common.HexToAddress("0x1")
+	assert.Equal(t, 7, int(nextNonce(t, nt, 1, sender)))
+	assert.Equal(t, 8, int(nextNonce(t, nt, 1, sender)))
+
+	// Node restarted and reports a lower nonce.
+	pending = 3
+	assert.Equal(t, 9, int(nextNonce(t, nt, 1, sender)))
+
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, 3, int(nextNonce(t, nt, 1, sender)))
+	assert.Equal(t, 4, int(nextNonce(t, nt, 1, sender)))
+}
+
+func TestNonceTrackerWithoutCache(t *testing.T) {
+	var pending uint64 = 7
+	cl := &mocks.EtherClientMock{
+		PendingNonceAtFunc: func(ctx context.Context, address common.Address) (uint64, error) {
+			return pending, nil
+		},
+	}
+	mbc := client.NewMultichainBlockchainClient(map[int64]client.BC{
+		1: client.NewBlockchain(client.NewDefaultAddressableEthClientGetter("", cl), time.Second),
+	})
+	nt := NewNonceTrackerWithoutCache(mbc, &mockStorage{deliveries: []Delivery{}})
+
+	sender := common.HexToAddress("0x1")
+	assert.NoError(t, nt.PrefetchNonces(context.Background(), 1, []common.Address{sender}))
+	assert.Len(t, cl.PendingNonceAtCalls(), 0)
+
+	assert.Equal(t, 7, int(nextNonce(t, nt, 1, sender)))
+	// Another process sent a transaction with the same key.
+	pending = 8
+	assert.Equal(t, 8, int(nextNonce(t, nt, 1, sender)))
+	assert.Equal(t, 8, int(nextNonce(t, nt, 1, sender)))
+	assert.Len(t, cl.PendingNonceAtCalls(), 3)
+}
+
+func TestNonceTrackerPrefetchNonces(t *testing.T) {
+	var lock sync.Mutex
+	calls := make(map[common.Address]int)
+	cl := &mocks.EtherClientMock{
+		PendingNonceAtFunc: func(ctx context.Context, address common.Address) (uint64, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			calls[address]++
+			return uint64(address.Big().Int64() * 10), nil
+		},
+	}
+	mbc := client.NewMultichainBlockchainClient(map[int64]client.BC{
+		1: client.NewBlockchain(client.NewDefaultAddressableEthClientGetter("", cl), time.Second),
+	})
+	nt := NewNonceTracker(mbc, &mockStorage{deliveries: []Delivery{}})
+
+	accounts := make([]common.Address, 20)
+	for i := range accounts {
+		accounts[i] = common.BigToAddress(big.NewInt(int64(i)))
+	}
+	// Already cached nonces are not overridden.
+	assert.Equal(t, 30, int(nextNonce(t, nt, 1, accounts[3])))
+
+	assert.NoError(t, nt.PrefetchNonces(context.Background(), 1, accounts))
+	for i, account := range accounts {
+		if i == 3 {
+			assert.Equal(t, 31, int(nextNonce(t, nt, 1, account)))
+			continue
+		}
+		assert.Equal(t, i*10, int(nextNonce(t, nt, 1, account)))
+		assert.Equal(t, i*10+1, int(nextNonce(t, nt, 1, account)))
+	}
+
+	for _, account := range accounts {
+		assert.Equal(t, 1, calls[account])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := nt.PrefetchNonces(ctx, 1, []common.Address{common.HexToAddress("0x100")})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNonceTrackerConcurrentEnqueue(t *testing.T) {
+	cl := &mocks.EtherClientMock{
+		PendingNonceAtFunc: func(ctx context.Context, address common.Address) (uint64, error) {
+			return 0, nil
+		},
+	}
+	mbc := client.NewMultichainBlockchainClient(map[int64]client.BC{
+		1: client.NewBlockchain(client.NewDefaultAddressableEthClientGetter("", cl), time.Second),
+	})
+	storage := &mockStorage{deliveries: []Delivery{}}
+	sender := common.HexToAddress("0x1")
+
+	const requests = 100
+	depot := NewDepot(&mockCourier{lastDeliveredNonce: -1}, storage, NewNonceTracker(mbc, storage), nil, DepotConfig{
+		MaxNonDelivered: requests,
+		Workers: []DepotWorker{
+			{Address: sender, ChainID: 1},
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := depot.EnqueueDelivery(DeliveryRequest{
+				ChainID: 1,
+				Sender:  sender,
+				Type:    "test",
+			}, false)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, requests, storage.length())
+	seen := make(map[uint64]bool)
+	for i := 0; i < storage.length(); i++ {
+		nonce := storage.get(i).Nonce
+		assert.False(t, seen[nonce], "nonce %d issued more than once", nonce)
+		assert.Less(t, nonce, uint64(requests))
+		seen[nonce] = true
+	}
+}
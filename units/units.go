@@ -20,6 +20,10 @@ var (
 	ethDecimals int32 = 18
 )
 
+// bigFloatPrec is the minimum precision used for big float conversions.
+// It is enough to represent any uint256 value exactly.
+const bigFloatPrec uint = 256
+
 // FloatGweiToBigIntWei returns the given gwei as wei.
 func FloatGweiToBigIntWei(gwei float64) *big.Int {
 	return singleStepMore(gwei)
@@ -72,6 +76,62 @@ func FloatEthToBigIntGwei(eth float64) *big.Int {
 	return singleStepMore(eth)
 }
 
+// WeiToEther returns the given wei as ether. The conversion is exact
+// for amounts which are whole numbers of ether.
+func WeiToEther(wei *big.Int) *big.Float {
+	return bigIntToBigFloat(wei, oneEthInWei)
+}
+
+// EtherToWei returns the given ether as wei, truncating anything below a single wei.
+// The conversion is exact for whole numbers of ether.
+func EtherToWei(ether *big.Float) *big.Int {
+	return bigFloatToBigInt(ether, oneEthInWei)
+}
+
+// WeiToGwei returns the given wei as gwei. The conversion is exact
+// for amounts which are whole numbers of gwei.
+func WeiToGwei(wei *big.Int) *big.Float {
+	return bigIntToBigFloat(wei, singleStep)
+}
+
+// GweiToWei returns the given gwei as wei, truncating anything below a single wei.
+// The conversion is exact for whole numbers of gwei.
+func GweiToWei(gwei *big.Float) *big.Int {
+	return bigFloatToBigInt(gwei, singleStep)
+}
+
+// GweiToEther returns the given gwei as ether.
+func GweiToEther(gwei *big.Int) *big.Float {
+	return bigIntToBigFloat(gwei, oneEthInGwei)
+}
+
+// EtherToGwei returns the given ether as gwei, truncating anything below a single gwei.
+func EtherToGwei(ether *big.Float) *big.Int {
+	return bigFloatToBigInt(ether, oneEthInGwei)
+}
+
+func bigIntToBigFloat(input, unit *big.Int) *big.Float {
+	prec := bigFloatPrec
+	if bits := uint(input.BitLen()); bits > prec {
+		prec = bits
+	}
+
+	f := new(big.Float).SetPrec(prec).SetInt(input)
+	return f.Quo(f, new(big.Float).SetPrec(prec).SetInt(unit))
+}
+
+func bigFloatToBigInt(input *big.Float, unit *big.Int) *big.Int {
+	// Precision of the product has to fit both operands for the result to be exact.
+	prec := input.MinPrec() + uint(unit.BitLen())
+	if prec < bigFloatPrec {
+		prec = bigFloatPrec
+	}
+
+	f := new(big.Float).SetPrec(prec).Set(input)
+	res, _ := f.Mul(f, new(big.Float).SetInt(unit)).Int(nil)
+	return res
+}
+
 func singleStepMore(input float64) *big.Int {
 	res, _ := new(big.Float).Mul(
 		big.NewFloat(input),
@@ -282,4 +282,61 @@ func TestUnits(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("big float", func(t *testing.T) {
+		mustBig := func(s string) *big.Int {
+			res, ok := new(big.Int).SetString(s, 10)
+			require.True(t, ok)
+			return res
+		}
+		mustFloat := func(s string) *big.Float {
+			res, ok := new(big.Float).SetPrec(bigFloatPrec).SetString(s)
+			require.True(t, ok)
+			return res
+		}
+		maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+		t.Run("ether and wei", func(t *testing.T) {
+			for _, test := range []struct {
+				ether string
+				wei   *big.Int
+			}{
+				{ether: "0", wei: big.NewInt(0)},
+				{ether: "1", wei: mustBig("1000000000000000000")},
+				{ether: "0.5", wei: mustBig("500000000000000000")},
+				{ether: "123456789", wei: mustBig("123456789000000000000000000")},
+				{ether: "-3", wei: mustBig("-3000000000000000000")},
+			} {
+				assert.Equal(t, 0, test.wei.Cmp(EtherToWei(mustFloat(test.ether))), test.ether)
+				assert.Equal(t, 0, mustFloat(test.ether).Cmp(WeiToEther(test.wei)), test.ether)
+			}
+
+			// Whole numbers of ether far beyond float64 precision.
+			huge := new(big.Int).Div(maxUint256, mustBig("1000000000000000000"))
+			wei := new(big.Int).Mul(huge, mustBig("1000000000000000000"))
+			ether := WeiToEther(wei)
+			assert.True(t, ether.IsInt())
+			assert.Equal(t, huge.String(), ether.Text('f', 0))
+			assert.Equal(t, 0, wei.Cmp(EtherToWei(ether)))
+
+			// Less than a wei is truncated.
+			assert.Equal(t, "1", EtherToWei(mustFloat("0.0000000000000000019")).String())
+		})
+
+		t.Run("gwei and wei", func(t *testing.T) {
+			assert.Equal(t, "1000000000", GweiToWei(mustFloat("1")).String())
+			assert.Equal(t, "1500000000", GweiToWei(mustFloat("1.5")).String())
+			assert.Equal(t, "1", GweiToWei(mustFloat("0.000000001")).String())
+			assert.Equal(t, "30", WeiToGwei(mustBig("30000000000")).Text('f', 0))
+			assert.Equal(t, "0.5", WeiToGwei(mustBig("500000000")).Text('f', 1))
+
+			wholeGwei := new(big.Int).Mul(new(big.Int).Div(maxUint256, singleStep), singleStep)
+			assert.Equal(t, wholeGwei.String(), GweiToWei(WeiToGwei(wholeGwei)).String())
+		})
+
+		t.Run("ether and gwei", func(t *testing.T) {
+			assert.Equal(t, "2000000000", EtherToGwei(mustFloat("2")).String())
+			assert.Equal(t, "2", GweiToEther(mustBig("2000000000")).Text('f', 0))
+		})
+	})
 }